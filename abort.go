@@ -0,0 +1,45 @@
+package pipeline
+
+import (
+	"errors"
+)
+
+// ErrAbort is the error that a step can return to stop the pipeline gracefully.
+// The remaining steps don't run, and the pipeline returns nil as if all steps were successful.
+// The step's error handler and the after hooks still receive ErrAbort.
+// See Options.AbortOn to treat other errors the same way.
+var ErrAbort = errors.New("pipeline aborted")
+
+// ErrSkipRemaining is the error that a step can return to stop the pipeline early, e.g. if a precondition isn't met.
+// The remaining steps don't run, and the pipeline returns ErrSkipRemaining itself instead of wrapping it in a Result.
+// Unlike ErrAbort, the pipeline is considered failed, but the caller can distinguish the intentional early exit from actual failures by comparing the returned error.
+// It also stops the pipeline if Options.ContinueOnError is enabled, in which case the errors collected so far are returned along with it.
+// In a nested pipeline (see Pipeline.AsNestedStep and Pipeline.WithNestedSteps), only the remaining steps of the nested pipeline are skipped.
+// The nested step then fails with the Result of the skipping step like with any other error, so that the parent pipeline doesn't stop unless configured otherwise.
+var ErrSkipRemaining = errors.New("remaining steps skipped")
+
+// isSkipRemaining returns true if the given error returned by a step is or wraps ErrSkipRemaining.
+// Errors that wrap the Result of another pipeline, e.g. of a nested pipeline, are not considered.
+func isSkipRemaining(err error) bool {
+	var nested Result
+	return errors.Is(err, ErrSkipRemaining) && !errors.As(err, &nested)
+}
+
+// skipsRemaining returns true if the given result is the failure of a step that returned ErrSkipRemaining.
+func skipsRemaining(result Result) bool {
+	r, ok := result.(resultImpl)
+	return ok && r.skipRemaining
+}
+
+// aborts returns true if the given error is ErrAbort or one of Options.AbortOn.
+func (o Options) aborts(err error) bool {
+	if errors.Is(err, ErrAbort) {
+		return true
+	}
+	for _, target := range o.AbortOn {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errNothingToDo = errors.New("nothing to do")
+
+func TestOptions_AbortOn(t *testing.T) {
+	tests := map[string]struct {
+		givenErr      error
+		givenOptions  Options
+		expectedCalls []string
+		expectedError string
+	}{
+		"GivenErrAbort_ThenStopGracefully": {
+			givenErr:      ErrAbort,
+			expectedCalls: []string{"first", "abort"},
+		},
+		"GivenWrappedErrAbort_ThenStopGracefully": {
+			givenErr:      fmt.Errorf("up to date: %w", ErrAbort),
+			expectedCalls: []string{"first", "abort"},
+		},
+		"GivenCustomSentinel_WhenInAbortOn_ThenStopGracefully": {
+			givenErr:      fmt.Errorf("check: %w", errNothingToDo),
+			givenOptions:  Options{AbortOn: []error{errors.New("other"), errNothingToDo}},
+			expectedCalls: []string{"first", "abort"},
+		},
+		"GivenCustomSentinel_WhenNotInAbortOn_ThenFail": {
+			givenErr:      errNothingToDo,
+			expectedCalls: []string{"first", "abort"},
+			expectedError: "step 'abort' failed: nothing to do",
+		},
+		"GivenCustomSentinel_WhenContinueOnError_ThenReturnCollectedErrors": {
+			givenErr:      errNothingToDo,
+			givenOptions:  Options{AbortOn: []error{errNothingToDo}, ContinueOnError: true},
+			expectedCalls: []string{"first", "abort"},
+			expectedError: "step 'first' failed: failed",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			p := NewPipeline[context.Context]().WithOptions(tc.givenOptions)
+			p.WithSteps(
+				p.NewStep("first", func(_ context.Context) error {
+					calls = append(calls, "first")
+					if tc.givenOptions.ContinueOnError {
+						return errors.New("failed")
+					}
+					return nil
+				}),
+				p.NewStep("abort", func(_ context.Context) error {
+					calls = append(calls, "abort")
+					return tc.givenErr
+				}),
+				p.NewStep("last", func(_ context.Context) error {
+					calls = append(calls, "last")
+					return nil
+				}),
+			)
+			summary, err := p.RunWithSummary(context.Background())
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 0, summary.Failed)
+			}
+			assert.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
+
+func TestErrSkipRemaining(t *testing.T) {
+	var calls []string
+	var finalized error
+	p := NewPipeline[context.Context]().WithFinalizer(func(_ context.Context, err error) error {
+		finalized = err
+		return err
+	})
+	p.WithSteps(
+		p.NewStep("check", func(_ context.Context) error {
+			calls = append(calls, "check")
+			return ErrSkipRemaining
+		}),
+		p.NewStep("never", func(_ context.Context) error {
+			calls = append(calls, "never")
+			return nil
+		}),
+	)
+	summary, err := p.RunWithSummary(context.Background())
+	assert.Equal(t, ErrSkipRemaining, err, "error is not wrapped")
+	assert.Equal(t, ErrSkipRemaining, finalized)
+	assert.Equal(t, []string{"check"}, calls)
+	assert.Equal(t, 1, summary.Failed)
+
+	p = NewPipeline[context.Context]().WithOptions(Options{ContinueOnError: true})
+	p.WithSteps(
+		p.NewStep("check", func(_ context.Context) error {
+			return fmt.Errorf("precondition: %w", ErrSkipRemaining)
+		}),
+		p.NewStep("never", failingAction),
+	)
+	err = p.RunWithContext(context.Background())
+	assert.Equal(t, ErrSkipRemaining, err, "stop despite ContinueOnError")
+}
+
+func TestErrSkipRemaining_NestedPipeline(t *testing.T) {
+	var calls []string
+	p := NewPipeline[context.Context]()
+	p.WithSteps(
+		p.WithNestedSteps("nested", nil,
+			p.NewStep("check", func(_ context.Context) error {
+				calls = append(calls, "check")
+				return ErrSkipRemaining
+			}),
+			p.NewStep("never", func(_ context.Context) error {
+				calls = append(calls, "never")
+				return nil
+			}),
+		),
+		p.NewStep("after", func(_ context.Context) error {
+			calls = append(calls, "after")
+			return nil
+		}),
+	)
+	err := p.RunWithContext(context.Background())
+	assert.EqualError(t, err, "step 'nested' failed: step 'check' failed: remaining steps skipped")
+	assert.ErrorIs(t, err, ErrSkipRemaining)
+	var result Result
+	require.ErrorAs(t, err, &result)
+	assert.Equal(t, "nested", result.Name())
+	assert.Equal(t, []string{"check"}, calls, "nested step fails like with any other error")
+
+	calls = nil
+	p = NewPipeline[context.Context]().WithOptions(Options{ContinueOnError: true})
+	p.WithSteps(
+		p.WithNestedSteps("nested", nil,
+			p.NewStep("check", func(_ context.Context) error {
+				calls = append(calls, "check")
+				return ErrSkipRemaining
+			}),
+			p.NewStep("never", func(_ context.Context) error {
+				calls = append(calls, "never")
+				return nil
+			}),
+		),
+		p.NewStep("after", func(_ context.Context) error {
+			calls = append(calls, "after")
+			return nil
+		}),
+	)
+	err = p.RunWithContext(context.Background())
+	assert.ErrorContains(t, err, "step 'nested' failed: step 'check' failed: remaining steps skipped")
+	assert.Equal(t, []string{"check", "after"}, calls, "parent continues on error")
+}
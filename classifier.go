@@ -0,0 +1,55 @@
+package pipeline
+
+import (
+	"errors"
+)
+
+// Classifier maps errors to categories, e.g. to bucket failures into "transient", "permanent" or "user-error".
+// Categories are determined by matching errors against registered sentinel errors with errors.Is.
+// This can be used in ErrorHandler and ParallelResultHandler funcs alike to centralize the error taxonomy.
+//
+// Note: Register is not thread-safe, register all sentinels before classifying errors.
+type Classifier struct {
+	categories []category
+}
+
+type category struct {
+	name      string
+	sentinels []error
+}
+
+// NewClassifier returns a new Classifier instance without any categories.
+func NewClassifier() *Classifier {
+	return &Classifier{}
+}
+
+// Register adds the given sentinel errors to the category and returns itself.
+// Categories are evaluated in the order they have been registered first, so the first matching category wins.
+// Registering the same category multiple times adds the sentinels to the existing category.
+func (c *Classifier) Register(categoryName string, sentinels ...error) *Classifier {
+	for i := range c.categories {
+		if c.categories[i].name == categoryName {
+			c.categories[i].sentinels = append(c.categories[i].sentinels, sentinels...)
+			return c
+		}
+	}
+	c.categories = append(c.categories, category{name: categoryName, sentinels: sentinels})
+	return c
+}
+
+// Classify returns the name of the first category that has a sentinel matching the given error with errors.Is.
+// Wrapped errors, e.g. Result, are unwrapped as usual.
+// It returns an empty string if err is nil or doesn't match any registered sentinel.
+func (c *Classifier) Classify(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, cat := range c.categories {
+		for _, sentinel := range cat.sentinels {
+			if errors.Is(err, sentinel) {
+				return cat.name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errTransient = errors.New("connection reset")
+	errPermanent = errors.New("not found")
+)
+
+func TestClassifier_Classify(t *testing.T) {
+	classifier := NewClassifier().
+		Register("transient", errTransient).
+		Register("permanent", errPermanent)
+	tests := map[string]struct {
+		givenError       error
+		expectedCategory string
+	}{
+		"GivenNil_ThenReturnEmpty": {
+			givenError:       nil,
+			expectedCategory: "",
+		},
+		"GivenSentinel_ThenReturnCategory": {
+			givenError:       errTransient,
+			expectedCategory: "transient",
+		},
+		"GivenWrappedSentinel_ThenReturnCategory": {
+			givenError:       fmt.Errorf("lookup: %w", errPermanent),
+			expectedCategory: "permanent",
+		},
+		"GivenResult_ThenReturnCategory": {
+			givenError:       newResult("step", fmt.Errorf("step 'step' failed: %w", errTransient), 0),
+			expectedCategory: "transient",
+		},
+		"GivenUnknownError_ThenReturnEmpty": {
+			givenError:       errors.New("unknown"),
+			expectedCategory: "",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedCategory, classifier.Classify(tc.givenError))
+		})
+	}
+}
+
+func TestClassifier_Register(t *testing.T) {
+	t.Run("GivenExistingCategory_ThenAddSentinels", func(t *testing.T) {
+		classifier := NewClassifier().
+			Register("transient", errTransient).
+			Register("transient", errPermanent)
+		assert.Equal(t, "transient", classifier.Classify(errPermanent))
+	})
+	t.Run("GivenSentinelInMultipleCategories_ThenFirstCategoryWins", func(t *testing.T) {
+		classifier := NewClassifier().
+			Register("first", errTransient).
+			Register("second", errTransient)
+		assert.Equal(t, "first", classifier.Classify(errTransient))
+	})
+}
+
+func ExampleClassifier() {
+	classifier := NewClassifier().
+		Register("transient", errTransient).
+		Register("permanent", errPermanent)
+
+	p := NewPipeline[context.Context]()
+	p.WithSteps(
+		p.NewStep("fetch", func(_ context.Context) error {
+			return errTransient
+		}),
+	)
+	err := p.RunWithContext(context.Background())
+	fmt.Println(classifier.Classify(err))
+	// Output: transient
+}
@@ -3,11 +3,69 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 )
 
 type contextKey struct{}
 
+// computeKey is the key under which LoadFromContextOrCompute synchronizes the computation of the value for key.
+type computeKey struct {
+	key any
+}
+
+// ContextDeriver is implemented by custom context types that can be rebuilt on top of a derived context.Context.
+// Features that derive a new context from the context given to Pipeline.RunWithContext (e.g. to add values or deadlines) require T to implement this interface, unless T is context.Context itself.
+type ContextDeriver[T context.Context] interface {
+	// DeriveContext returns a copy of the receiver that uses the given context as the underlying context.Context.
+	DeriveContext(ctx context.Context) T
+}
+
+// deriveContext returns ctx as T, either directly if T is context.Context, or by calling ContextDeriver.DeriveContext on parent.
+// It panics if neither is possible.
+func deriveContext[T context.Context](parent T, ctx context.Context) T {
+	if derived, ok := ctx.(T); ok {
+		return derived
+	}
+	if deriver, ok := any(parent).(ContextDeriver[T]); ok {
+		return deriver.DeriveContext(ctx)
+	}
+	panic(fmt.Errorf("cannot derive context: %T does not implement ContextDeriver", parent))
+}
+
+// contextError returns the error of the given canceled context.
+// If the context has been canceled with a cause (see context.WithCancelCause), the returned error wraps the cause as well, so that the original message is preserved.
+func contextError(ctx context.Context) error {
+	err := ctx.Err()
+	if cause := context.Cause(ctx); cause != nil && cause != err {
+		return fmt.Errorf("%w: %w", err, cause)
+	}
+	return err
+}
+
+// MergeContexts returns a context that is canceled when primary is canceled and has the same deadline as primary, but that also carries the values of valuesFrom.
+// A value is looked up in primary first, and only if primary returns nil for the key, it is looked up in valuesFrom.
+// The cancellation and deadline of valuesFrom are ignored.
+// Note that if only valuesFrom has been set up with MutableContext, the merged context shares the mutable values of valuesFrom.
+func MergeContexts(primary context.Context, valuesFrom context.Context) context.Context {
+	return mergedContext{Context: primary, values: valuesFrom}
+}
+
+// mergedContext is the context.Context returned by MergeContexts.
+type mergedContext struct {
+	context.Context
+	values context.Context
+}
+
+// Value implements context.Context.
+func (c mergedContext) Value(key any) any {
+	if value := c.Context.Value(key); value != nil {
+		return value
+	}
+	return c.values.Value(key)
+}
+
 // MutableContext adds a map to the given context that can be used to store mutable values in the context.
 // It uses sync.Map under the hood.
 // Repeated calls to MutableContext with the same parent has no effect and returns the same context.
@@ -20,6 +78,21 @@ func MutableContext(parent context.Context) context.Context {
 	return parent
 }
 
+// isolatedContext returns a child of ctx with its own mutable map, which is initialized with a copy of the values stored in ctx (if any).
+// Values stored in the child are not visible in ctx.
+func isolatedContext(ctx context.Context) context.Context {
+	m := &sync.Map{}
+	if parent, ok := ctx.Value(contextKey{}).(*sync.Map); ok {
+		parent.Range(func(key, value any) bool {
+			if _, internal := key.(computeKey); !internal {
+				m.Store(key, value)
+			}
+			return true
+		})
+	}
+	return context.WithValue(ctx, contextKey{}, m)
+}
+
 // StoreInContext adds the given key and value to ctx.
 // Any keys or values added during pipeline execution is available in the next steps, provided the pipeline runs synchronously.
 // In parallel executed pipelines you may encounter race conditions.
@@ -34,6 +107,21 @@ func StoreInContext(ctx context.Context, key, value any) {
 	m.(*sync.Map).Store(key, value)
 }
 
+// StoreAllInContext adds all the given keys and values to ctx, e.g. to set up multiple values in a single step.
+// See StoreInContext.
+//
+// Note: This method is thread-safe, but panics if ctx has not been set up with MutableContext first.
+func StoreAllInContext(ctx context.Context, kv map[any]any) {
+	m := ctx.Value(contextKey{})
+	if m == nil {
+		panic(fmt.Errorf("context was not set up with MutableContext()"))
+	}
+	mp := m.(*sync.Map)
+	for key, value := range kv {
+		mp.Store(key, value)
+	}
+}
+
 // LoadFromContext returns the value from the given context with the given key.
 // It returns the value and true, or nil and false if the key doesn't exist.
 // It returns nil and true if the key exists and the value actually is nil.
@@ -50,6 +138,65 @@ func LoadFromContext(ctx context.Context, key any) (any, bool) {
 	return val, found
 }
 
+// DeleteFromContext removes the value with the given key from ctx.
+// A subsequent LoadFromContext with the same key returns nil and false.
+// Use it to release large values that aren't needed in the next steps anymore.
+//
+// Note: This method is thread-safe, but panics if the ctx has not been set up with MutableContext first.
+func DeleteFromContext(ctx context.Context, key any) {
+	m := ctx.Value(contextKey{})
+	if m == nil {
+		panic(fmt.Errorf("context was not set up with MutableContext()"))
+	}
+	m.(*sync.Map).Delete(key)
+}
+
+// RangeContext calls fn sequentially for each key and value stored in ctx.
+// If fn returns false, the iteration stops.
+// It has the same semantics as sync.Map's Range, e.g. to dump the stored values for debugging purposes.
+//
+// Note: This method is thread-safe, but panics if the ctx has not been set up with MutableContext first.
+func RangeContext(ctx context.Context, fn func(key, value any) bool) {
+	m := ctx.Value(contextKey{})
+	if m == nil {
+		panic(fmt.Errorf("context was not set up with MutableContext()"))
+	}
+	m.(*sync.Map).Range(func(key, value any) bool {
+		if _, internal := key.(computeKey); internal {
+			return true
+		}
+		return fn(key, value)
+	})
+}
+
+// DumpContext returns the keys and values stored in ctx formatted like "key1=value1, key2=value2", e.g. to log them when a pipeline fails.
+// Keys and values are formatted with %v, and the pairs are sorted by the formatted key so that the output is deterministic.
+// Unlike RangeContext, it doesn't panic if ctx has not been set up with MutableContext, but returns "<not a mutable context>" instead.
+func DumpContext(ctx context.Context) string {
+	if ctx.Value(contextKey{}) == nil {
+		return "<not a mutable context>"
+	}
+	type pair struct {
+		key, value string
+	}
+	var pairs []pair
+	RangeContext(ctx, func(key, value any) bool {
+		pairs = append(pairs, pair{key: fmt.Sprintf("%v", key), value: fmt.Sprintf("%v", value)})
+		return true
+	})
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].key == pairs[j].key {
+			return pairs[i].value < pairs[j].value
+		}
+		return pairs[i].key < pairs[j].key
+	})
+	entries := make([]string, len(pairs))
+	for i, p := range pairs {
+		entries[i] = p.key + "=" + p.value
+	}
+	return strings.Join(entries, ", ")
+}
+
 // MustLoadFromContext is similar to LoadFromContext, except it doesn't return a bool to indicate whether the key exists.
 // It panics if the key doesn't exist.
 // Use StoreInContext to store values.
@@ -74,3 +221,65 @@ func LoadFromContextOrDefault(ctx context.Context, key any, defValue any) any {
 	}
 	return val
 }
+
+// LoadFromContextOrCompute is similar to LoadFromContext, except that if the key doesn't exist, it stores the value returned by compute in ctx and returns it.
+// compute is called at most once per key, even if LoadFromContextOrCompute is called concurrently, e.g. to lazily cache expensive values.
+// Other callers block until the value is computed, hence compute must not call LoadFromContextOrCompute with the same key.
+// If compute panics, no value is stored and the next call for the key invokes compute again.
+//
+// Note: This method is thread-safe, but panics if the ctx has not been set up with MutableContext first.
+func LoadFromContextOrCompute(ctx context.Context, key any, compute func() any) any {
+	m := ctx.Value(contextKey{})
+	if m == nil {
+		panic(fmt.Errorf("context was not set up with MutableContext()"))
+	}
+	mp := m.(*sync.Map)
+	if val, found := mp.Load(key); found {
+		return val
+	}
+	once, _ := mp.LoadOrStore(computeKey{key: key}, &sync.Once{})
+	once.(*sync.Once).Do(func() {
+		// removing the Once even if compute panics allows later calls to compute the value again.
+		defer mp.Delete(computeKey{key: key})
+		if _, found := mp.Load(key); !found {
+			mp.LoadOrStore(key, compute())
+		}
+	})
+	val, _ := mp.Load(key)
+	return val
+}
+
+// StoreTyped is similar to StoreInContext, except the value is of type V.
+// Use LoadTyped or MustLoadTyped to retrieve the value without type assertions.
+//
+// Note: This method is thread-safe, but panics if ctx has not been set up with MutableContext first.
+func StoreTyped[V any](ctx context.Context, key any, value V) {
+	StoreInContext(ctx, key, value)
+}
+
+// LoadTyped is similar to LoadFromContext, except the value is returned as type V.
+// It returns the zero value and false if the key doesn't exist or if the value is not of type V.
+//
+// Note: This method is thread-safe, but panics if the ctx has not been set up with MutableContext first.
+func LoadTyped[V any](ctx context.Context, key any) (V, bool) {
+	val, found := LoadFromContext(ctx, key)
+	if !found {
+		var zero V
+		return zero, false
+	}
+	v, ok := val.(V)
+	return v, ok
+}
+
+// MustLoadTyped is similar to LoadTyped, except it doesn't return a bool to indicate whether the key exists.
+// It panics if the key doesn't exist or if the value is not of type V.
+//
+// Note: This method is thread-safe, but panics if the ctx has not been set up with MutableContext first.
+func MustLoadTyped[V any](ctx context.Context, key any) V {
+	val := MustLoadFromContext(ctx, key)
+	v, ok := val.(V)
+	if !ok {
+		panic(fmt.Errorf("value of key %q is of type %T, but %T is required", key, val, v))
+	}
+	return v
+}
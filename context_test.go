@@ -3,7 +3,10 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -52,6 +55,59 @@ func TestContextPanics(t *testing.T) {
 	assert.PanicsWithError(t, "context was not set up with MutableContext()", func() {
 		LoadFromContext(context.Background(), "key")
 	}, "LoadFromContext")
+	assert.PanicsWithError(t, "context was not set up with MutableContext()", func() {
+		DeleteFromContext(context.Background(), "key")
+	}, "DeleteFromContext")
+	assert.PanicsWithError(t, "context was not set up with MutableContext()", func() {
+		RangeContext(context.Background(), func(_, _ any) bool { return true })
+	}, "RangeContext")
+	assert.PanicsWithError(t, "context was not set up with MutableContext()", func() {
+		StoreAllInContext(context.Background(), map[any]any{"key": "value"})
+	}, "StoreAllInContext")
+}
+
+func TestDumpContext(t *testing.T) {
+	ctx := MutableContext(context.Background())
+	assert.Empty(t, DumpContext(ctx))
+
+	StoreAllInContext(ctx, map[any]any{"b": 2, "a": "value", mergeKey("c"): []string{"x", "y"}, 10: nil})
+	LoadFromContextOrCompute(ctx, "computed", func() any { return true })
+	assert.Equal(t, "10=<nil>, a=value, b=2, c=[x y], computed=true", DumpContext(ctx))
+
+	assert.Equal(t, "<not a mutable context>", DumpContext(context.Background()))
+}
+
+func TestStoreAllInContext(t *testing.T) {
+	ctx := MutableContext(context.Background())
+	StoreInContext(ctx, "existing", "old")
+	StoreAllInContext(ctx, map[any]any{"existing": "new", "number": 42, mergeKey("typed"): true})
+
+	assert.Equal(t, "new", MustLoadFromContext(ctx, "existing"))
+	assert.Equal(t, 42, MustLoadFromContext(ctx, "number"))
+	assert.Equal(t, true, MustLoadFromContext(ctx, mergeKey("typed")))
+}
+
+type mergeKey string
+
+func TestMergeContexts(t *testing.T) {
+	deadline := time.Now().Add(time.Hour)
+	primary, cancel := context.WithDeadline(context.WithValue(context.Background(), mergeKey("shared"), "primary"), deadline)
+	defer cancel()
+	secondary := context.WithValue(context.WithValue(context.Background(), mergeKey("shared"), "secondary"), mergeKey("secondary"), "value")
+	secondary, cancelSecondary := context.WithCancel(secondary)
+	cancelSecondary()
+
+	merged := MergeContexts(primary, secondary)
+	actualDeadline, hasDeadline := merged.Deadline()
+	assert.True(t, hasDeadline)
+	assert.Equal(t, deadline, actualDeadline)
+	assert.NoError(t, merged.Err(), "cancellation of secondary is ignored")
+	assert.Equal(t, "primary", merged.Value(mergeKey("shared")), "primary takes precedence")
+	assert.Equal(t, "value", merged.Value(mergeKey("secondary")))
+	assert.Nil(t, merged.Value(mergeKey("unknown")))
+
+	cancel()
+	assert.ErrorIs(t, merged.Err(), context.Canceled)
 }
 
 func TestMutableContextRepeated(t *testing.T) {
@@ -83,6 +139,40 @@ func TestMustLoadFromContext(t *testing.T) {
 	})
 }
 
+func TestDeleteFromContext(t *testing.T) {
+	ctx := MutableContext(context.Background())
+	StoreInContext(ctx, "key", "value")
+	StoreInContext(ctx, "other", "value")
+	DeleteFromContext(ctx, "key")
+	result, found := LoadFromContext(ctx, "key")
+	assert.Nil(t, result)
+	assert.False(t, found)
+	assert.Equal(t, "value", MustLoadFromContext(ctx, "other"), "other keys are retained")
+	assert.NotPanics(t, func() {
+		DeleteFromContext(ctx, "key")
+	}, "deleting non-existent key")
+}
+
+func TestRangeContext(t *testing.T) {
+	ctx := MutableContext(context.Background())
+	StoreInContext(ctx, "key1", "value1")
+	StoreInContext(ctx, "key2", 2)
+	StoreInContext(ctx, "key3", nil)
+	visited := map[any]any{}
+	RangeContext(ctx, func(key, value any) bool {
+		visited[key] = value
+		return true
+	})
+	assert.Equal(t, map[any]any{"key1": "value1", "key2": 2, "key3": nil}, visited)
+
+	calls := 0
+	RangeContext(ctx, func(_, _ any) bool {
+		calls++
+		return false
+	})
+	assert.Equal(t, 1, calls, "stop iteration")
+}
+
 func TestLoadFromContextOrDefault(t *testing.T) {
 	t.Run("KeyExists", func(t *testing.T) {
 		ctx := MutableContext(context.Background())
@@ -97,6 +187,117 @@ func TestLoadFromContextOrDefault(t *testing.T) {
 	})
 }
 
+func TestLoadFromContextOrCompute(t *testing.T) {
+	t.Run("KeyExists", func(t *testing.T) {
+		ctx := MutableContext(context.Background())
+		StoreInContext(ctx, "key", "value")
+		result := LoadFromContextOrCompute(ctx, "key", func() any {
+			t.Fail()
+			return "computed"
+		})
+		assert.Equal(t, "value", result)
+	})
+	t.Run("KeyDoesntExist", func(t *testing.T) {
+		ctx := MutableContext(context.Background())
+		result := LoadFromContextOrCompute(ctx, "key", func() any {
+			return "computed"
+		})
+		assert.Equal(t, "computed", result)
+		assert.Equal(t, "computed", MustLoadFromContext(ctx, "key"))
+	})
+	t.Run("Concurrent", func(t *testing.T) {
+		ctx := MutableContext(context.Background())
+		calls := int64(0)
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result := LoadFromContextOrCompute(ctx, "key", func() any {
+					atomic.AddInt64(&calls, 1)
+					return "computed"
+				})
+				assert.Equal(t, "computed", result)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int64(1), calls)
+		keys := 0
+		RangeContext(ctx, func(_, _ any) bool {
+			keys++
+			return true
+		})
+		assert.Equal(t, 1, keys, "no internal keys exposed")
+	})
+	t.Run("ComputePanics", func(t *testing.T) {
+		ctx := MutableContext(context.Background())
+		assert.Panics(t, func() {
+			LoadFromContextOrCompute(ctx, "key", func() any {
+				panic("boom")
+			})
+		})
+		_, found := LoadFromContext(ctx, "key")
+		assert.False(t, found, "no value stored")
+		result := LoadFromContextOrCompute(ctx, "key", func() any {
+			return 42
+		})
+		assert.Equal(t, 42, result, "key is not poisoned")
+	})
+}
+
+func TestLoadTyped(t *testing.T) {
+	tests := map[string]struct {
+		givenValue    any
+		expectedValue string
+		expectedFound bool
+	}{
+		"GivenNonExistentKey_ThenExpectZeroAndFalse": {
+			expectedValue: "",
+		},
+		"GivenValueOfWrongType_ThenExpectZeroAndFalse": {
+			givenValue:    1,
+			expectedValue: "",
+		},
+		"GivenValueOfCorrectType_ThenExpectValueAndTrue": {
+			givenValue:    "value",
+			expectedValue: "value",
+			expectedFound: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := MutableContext(context.Background())
+			if tc.givenValue != nil {
+				StoreInContext(ctx, "key", tc.givenValue)
+			}
+			result, found := LoadTyped[string](ctx, "key")
+			assert.Equal(t, tc.expectedValue, result, "value")
+			assert.Equal(t, tc.expectedFound, found, "value found")
+		})
+	}
+}
+
+func TestMustLoadTyped(t *testing.T) {
+	t.Run("KeyExistsWithValue", func(t *testing.T) {
+		ctx := MutableContext(context.Background())
+		StoreTyped(ctx, "key", 42)
+		assert.Equal(t, 42, MustLoadTyped[int](ctx, "key"))
+	})
+	t.Run("KeyDoesntExist", func(t *testing.T) {
+		assert.PanicsWithError(t, `key "key" was not found in context`, func() {
+			ctx := MutableContext(context.Background())
+			_ = MustLoadTyped[int](ctx, "key")
+		})
+	})
+	t.Run("ValueOfWrongType", func(t *testing.T) {
+		assert.PanicsWithError(t, `value of key "key" is of type string, but int is required`, func() {
+			ctx := MutableContext(context.Background())
+			StoreTyped(ctx, "key", "value")
+			_ = MustLoadTyped[int](ctx, "key")
+		})
+	})
+}
+
 func ExampleMutableContext() {
 	type key struct{}
 
@@ -0,0 +1,46 @@
+package pipeline
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// stepCounter counts the invocations of steps by their name.
+type stepCounter struct {
+	counts sync.Map
+}
+
+func (c *stepCounter) increment(name string) {
+	count, _ := c.counts.LoadOrStore(name, new(int64))
+	atomic.AddInt64(count.(*int64), 1)
+}
+
+func (c *stepCounter) get(name string) int64 {
+	count, found := c.counts.Load(name)
+	if !found {
+		return 0
+	}
+	return atomic.LoadInt64(count.(*int64))
+}
+
+// WithStepCounter enables counting how many times each step has run over the lifetime of the Pipeline, e.g. to profile pipelines that are run repeatedly.
+// Use StepCount to retrieve the counts.
+// A step counts as run if its action has been invoked, regardless of whether it failed.
+// Counting is safe for concurrent use, so the Pipeline can be run from multiple Go routines.
+func (p *Pipeline[T]) WithStepCounter() *Pipeline[T] {
+	p.mustNotBeFrozen()
+	if p.counter == nil {
+		p.counter = &stepCounter{}
+	}
+	return p
+}
+
+// StepCount returns how many times the steps with the given name have run since WithStepCounter has been called.
+// Steps that share the same name are not distinguishable.
+// It returns 0 if counting is not enabled.
+func (p *Pipeline[T]) StepCount(name string) int64 {
+	if p.counter == nil {
+		return 0
+	}
+	return p.counter.get(name)
+}
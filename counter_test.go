@@ -0,0 +1,37 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline_WithStepCounter(t *testing.T) {
+	p := NewPipeline[context.Context]().WithStepCounter()
+	p.WithSteps(
+		p.NewStep("first", func(_ context.Context) error { return nil }),
+		p.When(Bool[context.Context](false), "skipped", failingAction),
+		p.NewStep("second", func(_ context.Context) error { return nil }),
+	)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, p.RunWithContext(context.Background()))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(100), p.StepCount("first"))
+	assert.Equal(t, int64(100), p.StepCount("second"))
+	assert.Equal(t, int64(0), p.StepCount("skipped"))
+	assert.Equal(t, int64(0), p.StepCount("unknown"))
+}
+
+func TestPipeline_StepCount_Disabled(t *testing.T) {
+	p := NewPipeline[context.Context]().AddStepFromFunc("first", func(_ context.Context) error { return nil })
+	assert.NoError(t, p.RunWithContext(context.Background()))
+	assert.Equal(t, int64(0), p.StepCount("first"))
+}
@@ -0,0 +1,200 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrDependencyCycle is returned by Pipeline.RunTopological if the dependencies declared with Step.DependsOn form a cycle.
+var ErrDependencyCycle = errors.New("dependency cycle")
+
+// RunTopological is similar to RunWithContext, except the steps run in an order that satisfies the dependencies declared with Step.DependsOn.
+// Apart from that, the steps keep the order in which they have been added to the pipeline, and they run sequentially.
+// Before any step runs, it returns an error if the step names are not unique, if a step depends on an unknown step,
+// or if the dependencies form a cycle, in which case the error wraps ErrDependencyCycle.
+func (p *Pipeline[T]) RunTopological(ctx T) error {
+	steps, err := topologicalOrder(p.steps)
+	if err != nil {
+		return err
+	}
+	sorted := *p
+	sorted.steps = steps
+	return sorted.RunWithContext(ctx)
+}
+
+// RunDAG is similar to RunTopological, except the steps whose dependencies are satisfied run in parallel, each in its own Go routine.
+// At most maxConcurrency steps run at the same time, and the function panics if it's lower than 1.
+// Once a step fails, no more steps are started, and the error of the failed step is returned as Result after the running steps have finished.
+// With Options.ContinueOnError, the steps that don't depend on a failed step still run, and the errors are aggregated as usual.
+// Steps marked with Defer run afterwards as with RunWithContext, their position in the graph is ignored.
+//
+// Note that the hooks and the context are shared between steps running at the same time, so they have to be goroutine-safe.
+func (p *Pipeline[T]) RunDAG(ctx T, maxConcurrency int) error {
+	if maxConcurrency < 1 {
+		panic("concurrency cannot be lower than 1")
+	}
+	index, err := indexStepNames(p.steps)
+	if err != nil {
+		return err
+	}
+	if _, err := topologicalOrder(p.steps); err != nil {
+		return err
+	}
+	return p.run(ctx, &Summary{}, func(ctx T, options Options, summary *Summary) Result {
+		start := time.Now()
+		summary.Total = len(p.steps)
+		result := p.runGraph(ctx, options, summary, index, maxConcurrency, start)
+		return p.runDeferred(ctx, options, summary, result, start)
+	})
+}
+
+// graphResult is the outcome of a step run by runGraph.
+type graphResult struct {
+	index   int
+	result  Result
+	summary Summary
+}
+
+// runGraph runs the steps that aren't marked with Defer as soon as their dependencies have succeeded.
+func (p *Pipeline[T]) runGraph(ctx T, options Options, summary *Summary, index map[string]int, maxConcurrency int, start time.Time) Result {
+	pending := make([]int, len(p.steps))
+	dependents := make([][]int, len(p.steps))
+	var ready []int
+	for i, step := range p.steps {
+		if step.deferred {
+			continue
+		}
+		for _, dependency := range step.Dependencies {
+			if d := index[dependency]; !p.steps[d].deferred {
+				pending[i]++
+				dependents[d] = append(dependents[d], i)
+			}
+		}
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	results := make(chan graphResult)
+	errs := &MultiError{}
+	var result Result
+	aborted := false
+	running := 0
+	for {
+		for result == nil && !aborted && running < maxConcurrency && len(ready) > 0 {
+			if ctx.Err() != nil {
+				summary.Failed++
+				result = p.fail(contextError(ctx), p.steps[ready[0]], options, time.Since(start))
+				break
+			}
+			i := ready[0]
+			ready = ready[1:]
+			running++
+			go func() {
+				stepSummary := Summary{}
+				stepResult := p.runStep(ctx, p.steps[i], options, &stepSummary)
+				results <- graphResult{index: i, result: stepResult, summary: stepSummary}
+			}()
+		}
+		if running == 0 {
+			return p.aggregate(errs, result, start)
+		}
+		done := <-results
+		running--
+		summary.Ran += done.summary.Ran
+		summary.Skipped += done.summary.Skipped
+		summary.Failed += done.summary.Failed
+		if done.result != nil {
+			if options.aborts(done.result) {
+				aborted = true
+			} else if options.ContinueOnError {
+				errs.Append(done.result.Name(), done.result)
+			} else if result == nil {
+				result = done.result
+			}
+			continue
+		}
+		for _, dependent := range dependents[done.index] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+}
+
+// topologicalOrder returns the given steps sorted so that each step comes after its dependencies.
+// It's a depth-first search that visits the steps in the given order, hence independent steps keep their relative order.
+func topologicalOrder[T context.Context](steps []Step[T]) ([]Step[T], error) {
+	index, err := indexStepNames(steps)
+	if err != nil {
+		return nil, err
+	}
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make([]int, len(steps))
+	sorted := make([]Step[T], 0, len(steps))
+	var path []string
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch states[i] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := append(path[indexOf(path, steps[i].Name):], steps[i].Name)
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> "))
+		}
+		states[i] = visiting
+		path = append(path, steps[i].Name)
+		for _, dependency := range steps[i].Dependencies {
+			if err := visit(index[dependency]); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		states[i] = visited
+		sorted = append(sorted, steps[i])
+		return nil
+	}
+	for i := range steps {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// indexStepNames returns the index of each step by its name.
+// It returns an error if the names are not unique or if a step depends on an unknown step.
+func indexStepNames[T context.Context](steps []Step[T]) (map[string]int, error) {
+	index := make(map[string]int, len(steps))
+	for i, step := range steps {
+		if _, exists := index[step.Name]; exists {
+			return nil, fmt.Errorf("duplicate step name %q", step.Name)
+		}
+		index[step.Name] = i
+	}
+	for _, step := range steps {
+		for _, dependency := range step.Dependencies {
+			if _, exists := index[dependency]; !exists {
+				return nil, fmt.Errorf("step %q depends on unknown step %q", step.Name, dependency)
+			}
+		}
+	}
+	return index, nil
+}
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,187 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestPipeline_RunTopological(t *testing.T) {
+	tests := map[string]struct {
+		givenSteps    func(record func(name string) Step[context.Context]) []Step[context.Context]
+		expectedOrder []string
+		expectedError string
+	}{
+		"GivenNoDependencies_ThenKeepOrder": {
+			givenSteps: func(record func(name string) Step[context.Context]) []Step[context.Context] {
+				return []Step[context.Context]{record("a"), record("b"), record("c")}
+			},
+			expectedOrder: []string{"a", "b", "c"},
+		},
+		"GivenDiamondDependency_ThenRunDependenciesFirst": {
+			givenSteps: func(record func(name string) Step[context.Context]) []Step[context.Context] {
+				return []Step[context.Context]{
+					record("deploy").DependsOn("build frontend", "build backend"),
+					record("build backend").DependsOn("checkout"),
+					record("build frontend").DependsOn("checkout"),
+					record("checkout"),
+				}
+			},
+			expectedOrder: []string{"checkout", "build frontend", "build backend", "deploy"},
+		},
+		"GivenCycle_ThenReturnError": {
+			givenSteps: func(record func(name string) Step[context.Context]) []Step[context.Context] {
+				return []Step[context.Context]{
+					record("a").DependsOn("c"),
+					record("b").DependsOn("a"),
+					record("c").DependsOn("b"),
+				}
+			},
+			expectedError: "dependency cycle: a -> c -> b -> a",
+		},
+		"GivenSelfDependency_ThenReturnError": {
+			givenSteps: func(record func(name string) Step[context.Context]) []Step[context.Context] {
+				return []Step[context.Context]{record("a").DependsOn("a")}
+			},
+			expectedError: "dependency cycle: a -> a",
+		},
+		"GivenUnknownDependency_ThenReturnError": {
+			givenSteps: func(record func(name string) Step[context.Context]) []Step[context.Context] {
+				return []Step[context.Context]{record("a").DependsOn("unknown")}
+			},
+			expectedError: `step "a" depends on unknown step "unknown"`,
+		},
+		"GivenDuplicateStepNames_ThenReturnError": {
+			givenSteps: func(record func(name string) Step[context.Context]) []Step[context.Context] {
+				return []Step[context.Context]{record("a"), record("a")}
+			},
+			expectedError: `duplicate step name "a"`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var order []string
+			record := func(name string) Step[context.Context] {
+				return NewStep(name, func(_ context.Context) error {
+					order = append(order, name)
+					return nil
+				})
+			}
+			p := NewPipeline[context.Context]().WithSteps(tc.givenSteps(record)...)
+			err := p.RunTopological(context.Background())
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Empty(t, order, "no step should run")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOrder, order)
+		})
+	}
+}
+
+func TestPipeline_RunTopological_ErrorIs(t *testing.T) {
+	p := NewPipeline[context.Context]().WithSteps(
+		NewStep("a", failingAction).DependsOn("b"),
+		NewStep("b", failingAction).DependsOn("a"),
+	)
+	err := p.RunTopological(context.Background())
+	assert.ErrorIs(t, err, ErrDependencyCycle)
+	assert.Equal(t, []string{"a", "b"}, p.StepNames(), "pipeline unchanged")
+}
+
+func TestPipeline_RunDAG(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, name)
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	branch := func(name string) Step[context.Context] {
+		return NewStep(name, func(_ context.Context) error {
+			wg.Done()
+			wg.Wait() // both branches have to run at the same time
+			record(name)
+			return nil
+		})
+	}
+	p := NewPipeline[context.Context]().WithSteps(
+		NewStep("join", func(_ context.Context) error {
+			record("join")
+			return nil
+		}).DependsOn("left", "right"),
+		branch("left").DependsOn("init"),
+		branch("right").DependsOn("init"),
+		NewStep("init", func(_ context.Context) error {
+			record("init")
+			return nil
+		}),
+	)
+	err := p.RunDAG(context.Background(), 2)
+	require.NoError(t, err)
+	require.Len(t, order, 4)
+	assert.Equal(t, "init", order[0])
+	assert.ElementsMatch(t, []string{"left", "right"}, order[1:3])
+	assert.Equal(t, "join", order[3])
+}
+
+func TestPipeline_RunDAG_Failure(t *testing.T) {
+	tests := map[string]struct {
+		givenOptions  Options
+		expectedRuns  []string
+		expectedError string
+	}{
+		"GivenFailingStep_ThenAbortSchedule": {
+			expectedRuns:  []string{"fail"},
+			expectedError: "step 'fail' failed: failed",
+		},
+		"GivenFailingStep_WhenContinueOnError_ThenRunIndependentSteps": {
+			givenOptions:  Options{ContinueOnError: true},
+			expectedRuns:  []string{"fail", "independent"},
+			expectedError: "step 'fail' failed: failed",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+			var runs []string
+			p := NewPipeline[context.Context]().WithOptions(tc.givenOptions).WithSteps(
+				NewStep("fail", func(_ context.Context) error {
+					runs = append(runs, "fail")
+					return errors.New("failed")
+				}),
+				NewStep("dependent", func(_ context.Context) error {
+					runs = append(runs, "dependent")
+					return nil
+				}).DependsOn("fail"),
+				NewStep("independent", func(_ context.Context) error {
+					runs = append(runs, "independent")
+					return nil
+				}),
+			)
+			err := p.RunDAG(context.Background(), 1)
+			assert.EqualError(t, err, tc.expectedError)
+			var result Result
+			require.ErrorAs(t, err, &result)
+			if !tc.givenOptions.ContinueOnError {
+				assert.Equal(t, "fail", result.Name())
+			}
+			assert.Equal(t, tc.expectedRuns, runs)
+		})
+	}
+}
+
+func TestPipeline_RunDAG_InvalidConcurrency(t *testing.T) {
+	assert.PanicsWithValue(t, "concurrency cannot be lower than 1", func() {
+		_ = NewPipeline[context.Context]().RunDAG(context.Background(), 0)
+	})
+}
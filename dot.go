@@ -0,0 +1,69 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ToDOT returns the structure of the Pipeline in the Graphviz DOT language, e.g. for documentation purposes.
+// Each step is rendered as a node labelled with its name, connected with edges in the order of execution, starting from a point-shaped start node.
+// Steps with a Step.Condition are rendered with a dashed incoming edge, since they may be skipped.
+// Nested pipelines created with AsNestedStep or WithNestedSteps are rendered as clusters that contain their steps.
+// Steps that create pipelines at runtime (e.g. NewFanOutStep) are rendered as single nodes, since the steps are not run.
+func (p *Pipeline[T]) ToDOT() string {
+	w := &dotWriter[T]{}
+	w.line("", "digraph pipeline {")
+	w.line("\t", `"start" [shape=point];`)
+	w.steps(p.steps, "\t", "start")
+	w.line("", "}")
+	return w.b.String()
+}
+
+// dotWriter renders steps in the DOT language.
+// Since the Action of nested steps is opaque, it descends into nested pipelines using the metadata in Step.nestedSteps.
+type dotWriter[T context.Context] struct {
+	b     strings.Builder
+	count int
+}
+
+// steps writes the given steps and the edges between them, connecting the first step to the given predecessor node.
+// It returns the ID of the last node, which is the predecessor if there are no steps.
+func (w *dotWriter[T]) steps(steps []Step[T], indent, predecessor string) string {
+	last := predecessor
+	for _, step := range steps {
+		last = w.step(step, indent, last, step.Condition != nil)
+	}
+	return last
+}
+
+// step writes the node of the given step, or a cluster if it is a nested step, and returns the ID of the last node.
+// The edge from the predecessor is dashed if conditional is true.
+func (w *dotWriter[T]) step(step Step[T], indent, predecessor string, conditional bool) string {
+	w.count++
+	if step.nestedSteps != nil {
+		if nested := step.nestedSteps(); len(nested) > 0 {
+			w.line(indent, fmt.Sprintf("subgraph cluster_%d {", w.count))
+			w.line(indent+"\t", fmt.Sprintf("label=%q;", step.Name))
+			// the nested pipeline is entered through its first step, which is skipped if either the nested step or the first step is conditional.
+			last := w.step(nested[0], indent+"\t", predecessor, conditional || nested[0].Condition != nil)
+			last = w.steps(nested[1:], indent+"\t", last)
+			w.line(indent, "}")
+			return last
+		}
+	}
+	id := fmt.Sprintf("step_%d", w.count)
+	w.line(indent, fmt.Sprintf("%q [label=%q];", id, step.Name))
+	if conditional {
+		w.line(indent, fmt.Sprintf("%q -> %q [style=dashed];", predecessor, id))
+	} else {
+		w.line(indent, fmt.Sprintf("%q -> %q;", predecessor, id))
+	}
+	return id
+}
+
+func (w *dotWriter[T]) line(indent, s string) {
+	w.b.WriteString(indent)
+	w.b.WriteString(s)
+	w.b.WriteString("\n")
+}
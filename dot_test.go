@@ -0,0 +1,96 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline_ToDOT(t *testing.T) {
+	noop := func(_ context.Context) error { return nil }
+	tests := map[string]struct {
+		givenPipeline func() *Pipeline[context.Context]
+		expectedDOT   string
+	}{
+		"GivenNoSteps_ThenRenderStartOnly": {
+			givenPipeline: func() *Pipeline[context.Context] {
+				return NewPipeline[context.Context]()
+			},
+			expectedDOT: `digraph pipeline {
+	"start" [shape=point];
+}
+`,
+		},
+		"GivenSteps_ThenRenderInOrder": {
+			givenPipeline: func() *Pipeline[context.Context] {
+				return NewPipeline[context.Context]().WithSteps(
+					NewStep("first", noop),
+					NewStepIf(Bool[context.Context](false), "conditional", noop),
+					NewStep(`quoted "name"`, noop),
+				)
+			},
+			expectedDOT: `digraph pipeline {
+	"start" [shape=point];
+	"step_1" [label="first"];
+	"start" -> "step_1";
+	"step_2" [label="conditional"];
+	"step_1" -> "step_2" [style=dashed];
+	"step_3" [label="quoted \"name\""];
+	"step_2" -> "step_3";
+}
+`,
+		},
+		"GivenNestedSteps_ThenRenderClusters": {
+			givenPipeline: func() *Pipeline[context.Context] {
+				nested := NewPipeline[context.Context]().WithSteps(NewStep("inner", noop), NewStep("second inner", noop))
+				p := NewPipeline[context.Context]()
+				return p.WithSteps(
+					nested.AsNestedStep("nested"),
+					p.WithNestedSteps("conditional nested", Bool[context.Context](true), NewStep("third inner", noop)),
+					p.WithNestedSteps("empty nested", nil),
+				)
+			},
+			expectedDOT: `digraph pipeline {
+	"start" [shape=point];
+	subgraph cluster_1 {
+		label="nested";
+		"step_2" [label="inner"];
+		"start" -> "step_2";
+		"step_3" [label="second inner"];
+		"step_2" -> "step_3";
+	}
+	subgraph cluster_4 {
+		label="conditional nested";
+		"step_5" [label="third inner"];
+		"step_3" -> "step_5" [style=dashed];
+	}
+	"step_6" [label="empty nested"];
+	"step_5" -> "step_6";
+}
+`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedDOT, tc.givenPipeline().ToDOT())
+		})
+	}
+}
+
+func ExamplePipeline_ToDOT() {
+	p := NewPipeline[context.Context]()
+	p.WithSteps(
+		p.NewStep("checkout", func(_ context.Context) error { return nil }),
+		p.When(Bool[context.Context](false), "deploy", func(_ context.Context) error { return nil }),
+	)
+	fmt.Print(p.ToDOT())
+	// Output: digraph pipeline {
+	// 	"start" [shape=point];
+	// 	"step_1" [label="checkout"];
+	// 	"start" -> "step_1";
+	// 	"step_2" [label="deploy"];
+	// 	"step_1" -> "step_2" [style=dashed];
+	// }
+}
@@ -0,0 +1,45 @@
+package pipeline
+
+import (
+	"context"
+)
+
+// DryRun returns the names of the steps that would run, in order, without invoking any Step.Action.
+// Steps marked with Defer are listed at the end in the order they would run.
+// The Step.Condition of each step is evaluated with the given context, and steps whose condition evaluates to false are omitted.
+// Nested pipelines created with AsNestedStep or WithNestedSteps are expanded: the nested step is followed by its steps,
+// which are prefixed with the name of the nested step, e.g. "nested > inner".
+//
+// Note that the conditions are evaluated with the state of ctx as given, since no action runs that could alter it.
+// Conditions that depend on the outcome of previous steps may thus evaluate differently in an actual run.
+func (p *Pipeline[T]) DryRun(ctx T) []string {
+	return dryRun(ctx, p.steps, "")
+}
+
+func dryRun[T context.Context](ctx T, steps []Step[T], prefix string) []string {
+	names := make([]string, 0, len(steps))
+	var deferred []Step[T]
+	for _, step := range steps {
+		if step.deferred {
+			deferred = append([]Step[T]{step}, deferred...)
+			continue
+		}
+		names = dryRunStep(ctx, step, prefix, names)
+	}
+	for _, step := range deferred {
+		names = dryRunStep(ctx, step, prefix, names)
+	}
+	return names
+}
+
+func dryRunStep[T context.Context](ctx T, step Step[T], prefix string, names []string) []string {
+	if step.Condition != nil && !step.Condition(ctx) {
+		return names
+	}
+	name := prefix + step.Name
+	names = append(names, name)
+	if step.nestedSteps != nil {
+		names = append(names, dryRun(ctx, step.nestedSteps(), name+" > ")...)
+	}
+	return names
+}
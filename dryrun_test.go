@@ -0,0 +1,43 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline_DryRun(t *testing.T) {
+	p := NewPipeline[context.Context]()
+	nested := NewPipeline[context.Context]().WithSteps(
+		NewStep("inner", failingAction),
+		NewStep("skipped inner", failingAction).When(Bool[context.Context](false)),
+	)
+	p.WithSteps(
+		Defer(p.NewStep("deferred 1", failingAction)),
+		p.NewStep("first", failingAction),
+		p.When(Bool[context.Context](false), "skipped", failingAction),
+		p.When(Bool[context.Context](true), "conditional", failingAction),
+		nested.AsNestedStep("nested"),
+		p.WithNestedSteps("skipped nested", Bool[context.Context](false), p.NewStep("never", failingAction)),
+		Defer(p.NewStep("deferred 2", failingAction)),
+	)
+	names := p.DryRun(context.Background())
+	assert.Equal(t, []string{"first", "conditional", "nested", "nested > inner", "deferred 2", "deferred 1"}, names)
+	assert.Empty(t, NewPipeline[context.Context]().DryRun(context.Background()))
+}
+
+func ExamplePipeline_DryRun() {
+	p := NewPipeline[context.Context]()
+	p.WithSteps(
+		p.NewStep("backup", func(_ context.Context) error { return nil }),
+		p.When(Bool[context.Context](false), "delete", func(_ context.Context) error { return nil }),
+		p.NewStep("report", func(_ context.Context) error { return nil }),
+	)
+	for _, name := range p.DryRun(context.Background()) {
+		fmt.Println(name)
+	}
+	// Output: backup
+	// report
+}
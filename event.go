@@ -0,0 +1,78 @@
+package pipeline
+
+import (
+	"time"
+)
+
+// EventKind is the type of Event.
+type EventKind int
+
+const (
+	// StepStarted is emitted just before a step's action is invoked.
+	StepStarted EventKind = iota
+	// StepFinished is emitted after a step's action and error handler (if any) have returned.
+	// Event.Err is the step's error, if any.
+	StepFinished
+	// StepSkipped is emitted for each step that doesn't run, see Pipeline.WithSkippedHooks.
+	StepSkipped
+	// PipelineFinished is emitted after the pipeline and its finalizer (if any) have returned.
+	// Event.Err is the pipeline's final error, if any.
+	PipelineFinished
+)
+
+// String returns the name of the kind.
+func (k EventKind) String() string {
+	switch k {
+	case StepStarted:
+		return "StepStarted"
+	case StepFinished:
+		return "StepFinished"
+	case StepSkipped:
+		return "StepSkipped"
+	case PipelineFinished:
+		return "PipelineFinished"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event is emitted by a Pipeline to the channel given with Pipeline.WithEventChannel.
+type Event struct {
+	// Kind is the type of the event.
+	Kind EventKind
+	// StepName is the name of the step.
+	// It is empty for PipelineFinished.
+	StepName string
+	// Err is the error of the step or pipeline for StepFinished and PipelineFinished events respectively.
+	Err error
+	// Duration is the duration that the step ran for StepFinished events, or the duration of the whole run for PipelineFinished events.
+	Duration time.Duration
+}
+
+// WithEventChannel sets a channel to which the Pipeline emits an Event for each step that starts, finishes or is skipped, and once the pipeline has finished.
+// The channel is inherited by nested pipelines built with AsNestedStep or WithNestedSteps, which also emit PipelineFinished when they finish.
+//
+// By default, events are sent without blocking, which means they are dropped if the receiver can't keep up and the channel's buffer is full.
+// Use a sufficiently buffered channel, or enable Options.BlockOnEvents to apply back-pressure on the pipeline instead.
+// The channel is never closed by the Pipeline.
+// Without a channel (default), no events are created at all.
+func (p *Pipeline[T]) WithEventChannel(ch chan<- Event) *Pipeline[T] {
+	p.mustNotBeFrozen()
+	p.events = ch
+	return p
+}
+
+// emit sends the event to the event channel, if any.
+func (p *Pipeline[T]) emit(options Options, event Event) {
+	if p.events == nil {
+		return
+	}
+	if options.BlockOnEvents {
+		p.events <- event
+		return
+	}
+	select {
+	case p.events <- event:
+	default:
+	}
+}
@@ -0,0 +1,57 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline_WithEventChannel(t *testing.T) {
+	events := make(chan Event, 10)
+	p := NewPipeline[context.Context]().WithEventChannel(events)
+	p.WithSteps(
+		p.NewStep("first", func(_ context.Context) error { return nil }),
+		p.When(Bool[context.Context](false), "skipped", failingAction),
+		p.NewStep("second", func(_ context.Context) error { return errors.New("failed") }),
+	)
+	err := p.RunWithContext(context.Background())
+	require.Error(t, err)
+	close(events)
+
+	var kinds []string
+	var names []string
+	var last Event
+	for event := range events {
+		kinds = append(kinds, event.Kind.String())
+		names = append(names, event.StepName)
+		last = event
+	}
+	assert.Equal(t, []string{"StepStarted", "StepFinished", "StepSkipped", "StepStarted", "StepFinished", "PipelineFinished"}, kinds)
+	assert.Equal(t, []string{"first", "first", "skipped", "second", "second", ""}, names)
+	assert.Equal(t, err, last.Err)
+	assert.GreaterOrEqual(t, last.Duration, time.Duration(0))
+}
+
+func TestPipeline_WithEventChannel_BackPressure(t *testing.T) {
+	t.Run("GivenFullChannel_ThenDropEvents", func(t *testing.T) {
+		events := make(chan Event)
+		p := NewPipeline[context.Context]().WithEventChannel(events).AddStepFromFunc("step", func(_ context.Context) error { return nil })
+		assert.NoError(t, p.RunWithContext(context.Background()), "should not block")
+	})
+	t.Run("GivenBlockOnEvents_ThenDeliverAllEvents", func(t *testing.T) {
+		events := make(chan Event)
+		p := NewPipeline[context.Context]().WithEventChannel(events).WithOptions(Options{BlockOnEvents: true})
+		p.AddStepFromFunc("step", func(_ context.Context) error { return nil })
+		done := p.RunAsync(context.Background())
+		var kinds []EventKind
+		for i := 0; i < 3; i++ {
+			kinds = append(kinds, (<-events).Kind)
+		}
+		assert.NoError(t, <-done)
+		assert.Equal(t, []EventKind{StepStarted, StepFinished, PipelineFinished}, kinds)
+	})
+}
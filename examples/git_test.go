@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/ccremer/go-command-pipeline/predicates"
 )
 
 type GitContext struct {
@@ -20,7 +21,7 @@ type GitContext struct {
 func TestExample_Git(t *testing.T) {
 	p := pipeline.NewPipeline[context.Context]()
 	p.WithSteps(
-		p.When(pipeline.Not(DirExists("my-repo")),
+		p.When(pipeline.Not(predicates.DirExists[context.Context]("my-repo")),
 			"clone repository", CloneGitRepository(),
 		),
 		p.NewStep("checkout branch", CheckoutBranch()),
@@ -70,12 +71,3 @@ func execGitCommand(args ...string) error {
 	err := cmd.Run()
 	return err
 }
-
-func DirExists(path string) pipeline.Predicate[context.Context] {
-	return func(_ context.Context) bool {
-		if info, err := os.Stat(path); err != nil || !info.IsDir() {
-			return false
-		}
-		return true
-	}
-}
@@ -2,7 +2,10 @@ package pipeline
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
+	"time"
 )
 
 /*
@@ -14,29 +17,156 @@ If the given ParallelResultHandler is non-nil it will be called after all pipeli
 If the context is canceled, no new pipelines will be retrieved from the channel and the Supplier is expected to stop supplying new instances.
 Also, once canceled, the step waits for the remaining children pipelines and collects their result via given ParallelResultHandler.
 However, the error returned from ParallelResultHandler is wrapped in context.Canceled.
+
+If a child pipeline panics, the panic is recovered and the child's result is an error that unwraps to ErrPanic.
 */
 func NewFanOutStep[T context.Context](name string, pipelineSupplier Supplier[T], handler ParallelResultHandler[T]) Step[T] {
+	return NewFanOutStepWithOptions[T](name, pipelineSupplier, handler, ParallelOptions{})
+}
+
+// NewFanOutStepWithOptions is NewFanOutStep, but the step's behaviour can be altered with ParallelOptions.
+func NewFanOutStepWithOptions[T context.Context](name string, pipelineSupplier Supplier[T], handler ParallelResultHandler[T], options ParallelOptions) Step[T] {
+	step := Step[T]{Name: name}
+	step.Action = func(ctx T) error {
+		res := fanOut(ctx, pipelineSupplier, collectErrors(handler), options, nil)
+		return setResultErrorFromContext(ctx, name, res)
+	}
+	return step
+}
+
+// NewFanOutStepWithResults is similar to NewFanOutStep, but the given ParallelResultMapHandler receives the errors of the child pipelines as Result.
+func NewFanOutStepWithResults[T context.Context](name string, pipelineSupplier Supplier[T], handler ParallelResultMapHandler[T]) Step[T] {
+	return NewFanOutStep[T](name, pipelineSupplier, handleResults(handler))
+}
+
+// NewFanOutStepWithDurations is similar to NewFanOutStep, but the given ParallelDurationHandler also receives the duration of each child pipeline, e.g. to identify the slowest child pipeline.
+func NewFanOutStepWithDurations[T context.Context](name string, pipelineSupplier Supplier[T], handler ParallelDurationHandler[T]) Step[T] {
+	step := Step[T]{Name: name}
+	step.Action = func(ctx T) error {
+		res := fanOut(ctx, pipelineSupplier, collectDurations(handler), ParallelOptions{}, nil)
+		return setResultErrorFromContext(ctx, name, res)
+	}
+	return step
+}
+
+// NewFanOutStepE is similar to NewFanOutStep, but the step fails if the given SupplierE returns an error.
+// The pipelines that have been supplied before the error occurred are still run, and their results are passed to the ParallelResultHandler.
+// The supplier's error is joined with the error returned from the ParallelResultHandler, so that a failing supplier doesn't silently truncate the work.
+func NewFanOutStepE[T context.Context](name string, pipelineSupplier SupplierE[T], handler ParallelResultHandler[T]) Step[T] {
+	step := Step[T]{Name: name}
+	step.Action = func(ctx T) error {
+		var supplierErr error
+		supplier := func(ctx T, pipelinesChan chan *Pipeline[T]) {
+			defer close(pipelinesChan)
+			supplierErr = pipelineSupplier(ctx, pipelinesChan)
+		}
+		res := fanOut(ctx, supplier, collectErrors(handler), ParallelOptions{}, nil)
+		if supplierErr != nil {
+			res = errors.Join(fmt.Errorf("supplier failed: %w", supplierErr), res)
+		}
+		return setResultErrorFromContext(ctx, name, res)
+	}
+	return step
+}
+
+// NewFanOutStepStreaming is similar to NewFanOutStep, but onResult is invoked as soon as a child pipeline completes, e.g. to report progress.
+// The index is the zero-based index in which the pipeline has been supplied, see ParallelResultHandler.
+// onResult is invoked from the Go routine of the completed child pipeline.
+// The invocations are serialized, but onResult must be goroutine-safe if it accesses state that is shared with other Go routines.
+// Once all child pipelines are done, the step returns the errors of the failed child pipelines combined with AggregateErrors.
+func NewFanOutStepStreaming[T context.Context](name string, pipelineSupplier Supplier[T], onResult func(ctx T, index uint64, err error)) Step[T] {
+	step := Step[T]{Name: name}
+	step.Action = func(ctx T) error {
+		var mu sync.Mutex
+		done := func(index uint64, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			onResult(ctx, index, err)
+		}
+		res := fanOut(ctx, pipelineSupplier, collectErrors(AggregateErrors[T]()), ParallelOptions{}, done)
+		return setResultErrorFromContext(ctx, name, res)
+	}
+	return step
+}
+
+// fanOut runs each supplied pipeline in its own Go routine and returns the error of the handler that the results are collected for.
+// If done is non-nil, it's invoked after each pipeline with its result.
+// It returns once the channel has been closed by the Supplier and all pipelines are done.
+func fanOut[T context.Context](ctx T, pipelineSupplier Supplier[T], collect resultCollector[T], options ParallelOptions, done func(index uint64, err error)) error {
+	pipelineChan := make(chan *Pipeline[T])
+	m := sync.Map{}
+	var wg sync.WaitGroup
+	i := uint64(0)
+	runner := newChildRunner(ctx, options)
+	defer runner.close()
+
+	go pipelineSupplier(runner.ctx, pipelineChan)
+	for pipe := range pipelineChan {
+		p := pipe
+		wg.Add(1)
+		n := i
+		i++
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := runner.run(p.RunWithContext)
+			m.Store(n, ParallelResult{Index: n, Err: err, Duration: time.Since(start)})
+			if done != nil {
+				done(n, err)
+			}
+		}()
+	}
+	waitForChildren(runner.ctx, &wg, options, &i, &m, nil)
+	return collect(ctx, &m)
+}
+
+// TypedSupplier is similar to Supplier, but it supplies units of work that return a typed result instead of pipelines.
+// The function must close the channel once all units are supplied (`defer close()` recommended).
+type TypedSupplier[T context.Context, R any] func(ctx T, unitsChan chan func(ctx T) (R, error))
+
+// TypedResultHandler is similar to ParallelResultHandler, but it receives the typed results of units supplied by a TypedSupplier.
+// The map keys are the zero-based index in which the units have been supplied.
+// The results map contains the results of the units that returned no error, while the errs map contains the errors of the failed units.
+type TypedResultHandler[T context.Context, R any] func(ctx T, results map[uint64]R, errs map[uint64]error) error
+
+// NewTypedFanOutStep is similar to NewFanOutStep, but each unit in its own Go routine returns a typed result alongside an error.
+// This allows fanning out computations and reducing their results in the given handler.
+// If handler is nil, the step is considered successful.
+// The cancellation behaviour is the same as NewFanOutStep.
+func NewTypedFanOutStep[T context.Context, R any](name string, supplier TypedSupplier[T, R], handler TypedResultHandler[T, R]) Step[T] {
 	step := Step[T]{Name: name}
 	step.Action = func(ctx T) error {
-		pipelineChan := make(chan *Pipeline[T])
-		m := sync.Map{}
+		unitsChan := make(chan func(ctx T) (R, error))
+		results := make(map[uint64]R)
+		errs := make(map[uint64]error)
+		var mu sync.Mutex
 		var wg sync.WaitGroup
 		i := uint64(0)
 
-		go pipelineSupplier(ctx, pipelineChan)
-		for pipe := range pipelineChan {
-			p := pipe
+		go supplier(ctx, unitsChan)
+		for unit := range unitsChan {
+			u := unit
 			wg.Add(1)
 			n := i
 			i++
 			go func() {
 				defer wg.Done()
-				m.Store(n, p.RunWithContext(ctx))
+				result, err := u(ctx)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errs[n] = err
+					return
+				}
+				results[n] = result
 			}()
 		}
 		wg.Wait()
-		res := collectResults(ctx, handler, &m)
-		return setResultErrorFromContext(ctx, name, res)
+		var err error
+		if handler != nil {
+			err = handler(ctx, results, errs)
+		}
+		return setResultErrorFromContext(ctx, name, err)
 	}
 	return step
 }
@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync/atomic"
 	"testing"
@@ -96,6 +97,275 @@ func TestNewFanOutStep_Cancel(t *testing.T) {
 	assert.EqualError(t, err, `step 'fanout' failed: context deadline exceeded, collection error: some error`)
 }
 
+func TestNewFanOutStepWithOptions_GracePeriod(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	stubbornDone := make(chan struct{})
+	step := NewFanOutStepWithOptions[context.Context]("fanout", SupplierFromSlice([]*Pipeline[context.Context]{
+		NewPipeline[context.Context]().AddStepFromFunc("cooperative", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+		NewPipeline[context.Context]().AddStepFromFunc("stubborn", func(_ context.Context) error {
+			defer close(stubbornDone)
+			time.Sleep(200 * time.Millisecond) // ignores cancellation
+			return nil
+		}),
+	}), func(_ context.Context, results map[uint64]error) error {
+		require.Len(t, results, 2)
+		assert.EqualError(t, results[0], "step 'cooperative' failed: context canceled")
+		assert.ErrorIs(t, results[1], ErrAbandoned)
+		return nil
+	}, ParallelOptions{GracePeriod: 20 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	start := time.Now()
+	err := step.Action(ctx)
+	elapsed := time.Since(start)
+	assert.EqualError(t, err, "context canceled")
+	assert.Less(t, elapsed, 150*time.Millisecond, "step should not wait for abandoned pipeline")
+	<-stubbornDone
+}
+
+func TestNewFanOutStepWithOptions_FailFast(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	step := NewFanOutStepWithOptions[context.Context]("fanout", SupplierFromSlice([]*Pipeline[context.Context]{
+		NewPipeline[context.Context]().AddStepFromFunc("wait", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+		NewPipeline[context.Context]().AddStepFromFunc("fail", func(_ context.Context) error {
+			time.Sleep(5 * time.Millisecond)
+			return errors.New("boom")
+		}),
+		NewPipeline[context.Context]().AddStepFromFunc("success", func(_ context.Context) error {
+			return nil
+		}),
+	}), func(_ context.Context, results map[uint64]error) error {
+		require.Len(t, results, 3)
+		assert.ErrorIs(t, results[0], ErrSiblingFailed)
+		assert.EqualError(t, results[0], "canceled due to failed sibling pipeline: step 'wait' failed: context canceled")
+		assert.EqualError(t, results[1], "step 'fail' failed: boom")
+		assert.NoError(t, results[2])
+		return nil
+	}, ParallelOptions{FailFast: true})
+	err := step.Action(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestNewFanOutStep_Panic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	pipes := []*Pipeline[context.Context]{
+		NewPipeline[context.Context]().AddStepFromFunc("ok", func(_ context.Context) error { return nil }),
+		NewPipeline[context.Context]().AddStepFromFunc("panic", func(_ context.Context) error { panic(errors.New("boom")) }),
+		NewPipeline[context.Context]().AddStepFromFunc("ok", func(_ context.Context) error { return nil }),
+	}
+	var results map[uint64]Result
+	step := NewFanOutStepWithResults("fanout", SupplierFromSlice(pipes), func(_ context.Context, r map[uint64]Result) error {
+		results = r
+		return nil
+	})
+	err := step.Action(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Nil(t, results[0])
+	assert.Nil(t, results[2])
+	require.NotNil(t, results[1])
+	assert.ErrorIs(t, results[1], ErrPanic)
+	var panicErr *PanicError
+	require.ErrorAs(t, results[1], &panicErr)
+	assert.EqualError(t, panicErr.Value.(error), "boom")
+}
+
+func TestNewFanOutStepWithResults(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	pipes := []*Pipeline[context.Context]{
+		NewPipeline[context.Context]().AddStepFromFunc("failing child", func(_ context.Context) error {
+			return errors.New("failed")
+		}),
+		NewPipeline[context.Context]().AddStepFromFunc("successful child", func(_ context.Context) error {
+			return nil
+		}),
+		NewPipeline[context.Context]().AddStepFromFunc("canceled child", func(_ context.Context) error {
+			return context.Canceled
+		}).WithFinalizer(func(_ context.Context, err error) error {
+			return fmt.Errorf("finalized: %w", err)
+		}),
+	}
+	var results map[uint64]Result
+	step := NewFanOutStepWithResults("fanout", SupplierFromSlice(pipes), func(_ context.Context, r map[uint64]Result) error {
+		results = r
+		return nil
+	})
+	err := step.Action(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.NotNil(t, results[0])
+	assert.Equal(t, "failing child", results[0].Name())
+	assert.EqualError(t, results[0], "step 'failing child' failed: failed")
+	assert.False(t, results[0].IsCanceled())
+	assert.Nil(t, results[1])
+	require.NotNil(t, results[2])
+	assert.Empty(t, results[2].Name(), "error replaced by finalizer")
+	assert.True(t, results[2].IsCanceled())
+}
+
+func TestNewFanOutStepWithOptions_MaxErrors(t *testing.T) {
+	tests := map[string]struct {
+		givenMaxErrors     int
+		expectSiblingError bool
+	}{
+		"GivenMaxErrorsReached_ThenCancelRemainingChildren": {
+			givenMaxErrors:     3,
+			expectSiblingError: true,
+		},
+		"GivenMaxErrorsNotReached_ThenRunAllChildren": {
+			givenMaxErrors: 4,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+			pipes := make([]*Pipeline[context.Context], 10)
+			for i := range pipes {
+				n := i
+				pipes[i] = NewPipeline[context.Context]().AddStepFromFunc(fmt.Sprintf("job %d", n), func(ctx context.Context) error {
+					if n < 3 {
+						time.Sleep(time.Duration(n+1) * 5 * time.Millisecond)
+						return errors.New("boom")
+					}
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case <-time.After(200 * time.Millisecond):
+						return nil
+					}
+				})
+			}
+			step := NewFanOutStepWithOptions("fanout", SupplierFromSlice(pipes), func(_ context.Context, results map[uint64]error) error {
+				require.Len(t, results, 10)
+				for n := uint64(0); n < 3; n++ {
+					assert.EqualError(t, results[n], fmt.Sprintf("step 'job %d' failed: boom", n))
+				}
+				for n := uint64(3); n < 10; n++ {
+					if tc.expectSiblingError {
+						assert.ErrorIs(t, results[n], ErrSiblingFailed)
+					} else {
+						assert.NoError(t, results[n])
+					}
+				}
+				return nil
+			}, ParallelOptions{MaxErrors: tc.givenMaxErrors})
+			err := step.Action(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewFanOutStepWithDurations(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	sleeps := []time.Duration{10 * time.Millisecond, 60 * time.Millisecond, 0}
+	pipes := make([]*Pipeline[context.Context], len(sleeps))
+	for i, sleep := range sleeps {
+		d := sleep
+		pipes[i] = NewPipeline[context.Context]().AddStepFromFunc("sleep", func(_ context.Context) error {
+			time.Sleep(d)
+			if d == 0 {
+				return errors.New("failed")
+			}
+			return nil
+		})
+	}
+	var results map[uint64]ParallelResult
+	step := NewFanOutStepWithDurations("fanout", SupplierFromSlice(pipes), func(_ context.Context, r map[uint64]ParallelResult) error {
+		results = r
+		return nil
+	})
+	err := step.Action(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for n, result := range results {
+		assert.Equal(t, n, result.Index)
+		assert.GreaterOrEqual(t, result.Duration, sleeps[n])
+	}
+	assert.Greater(t, results[1].Duration, results[0].Duration, "slow child")
+	assert.NoError(t, results[0].Err)
+	assert.EqualError(t, results[2].Err, "step 'sleep' failed: failed")
+}
+
+func TestNewFanOutStepE(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ran := int64(0)
+	step := NewFanOutStepE[context.Context]("fanout", func(_ context.Context, pipelines chan *Pipeline[context.Context]) error {
+		pipelines <- NewPipeline[context.Context]().AddStepFromFunc("child", func(_ context.Context) error {
+			atomic.AddInt64(&ran, 1)
+			return nil
+		})
+		return errors.New("cannot load config")
+	}, AggregateErrors[context.Context]())
+	err := NewPipeline[context.Context]().AddStep(step).RunWithContext(context.Background())
+	assert.EqualError(t, err, "step 'fanout' failed: supplier failed: cannot load config")
+	assert.Equal(t, int64(1), ran, "supplied pipeline should run")
+
+	step = NewFanOutStepE[context.Context]("fanout", func(_ context.Context, pipelines chan *Pipeline[context.Context]) error {
+		pipelines <- NewPipeline[context.Context]().AddStepFromFunc("child", func(_ context.Context) error {
+			return errors.New("child failed")
+		})
+		return errors.New("cannot load config")
+	}, AggregateErrors[context.Context]())
+	err = step.Action(context.Background())
+	assert.EqualError(t, err, "supplier failed: cannot load config\nstep 'child' failed: child failed")
+}
+
+func TestNewFanOutStepStreaming(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	pipes := make([]*Pipeline[context.Context], 5)
+	for i := range pipes {
+		n := i
+		pipes[i] = NewPipeline[context.Context]().AddStepFromFunc(fmt.Sprintf("job %d", n), func(_ context.Context) error {
+			if n == 3 {
+				return errors.New("failed")
+			}
+			return nil
+		})
+	}
+	calls := 0
+	indices := map[uint64]error{}
+	step := NewFanOutStepStreaming("fanout", SupplierFromSlice(pipes), func(_ context.Context, index uint64, err error) {
+		calls++
+		indices[index] = err
+	})
+	err := step.Action(context.Background())
+	assert.EqualError(t, err, "step 'job 3' failed: failed")
+	assert.Equal(t, len(pipes), calls)
+	require.Len(t, indices, len(pipes))
+	assert.EqualError(t, indices[3], "step 'job 3' failed: failed")
+}
+
+func TestNewTypedFanOutStep(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	step := NewTypedFanOutStep[context.Context, string]("fanout", func(_ context.Context, units chan func(context.Context) (string, error)) {
+		defer close(units)
+		for i := 0; i < 4; i++ {
+			n := i
+			units <- func(_ context.Context) (string, error) {
+				if n%2 == 1 {
+					return "", fmt.Errorf("unit %d failed", n)
+				}
+				return fmt.Sprintf("unit %d", n), nil
+			}
+		}
+	}, func(_ context.Context, results map[uint64]string, errs map[uint64]error) error {
+		assert.Equal(t, map[uint64]string{0: "unit 0", 2: "unit 2"}, results)
+		require.Len(t, errs, 2)
+		assert.EqualError(t, errs[1], "unit 1 failed")
+		assert.EqualError(t, errs[3], "unit 3 failed")
+		return errs[1]
+	})
+	err := step.Action(context.Background())
+	assert.EqualError(t, err, "unit 1 failed")
+}
+
 func ExampleNewFanOutStep() {
 	p := NewPipeline[context.Context]()
 	fanout := NewFanOutStep[context.Context]("fanout", func(ctx context.Context, pipelines chan *Pipeline[context.Context]) {
@@ -129,3 +399,28 @@ func ExampleNewFanOutStep() {
 	// I am worker 1
 	// I am worker 2
 }
+
+func ExampleNewTypedFanOutStep() {
+	p := NewPipeline[context.Context]()
+	p.AddStep(NewTypedFanOutStep[context.Context, int]("sum", func(_ context.Context, units chan func(context.Context) (int, error)) {
+		defer close(units)
+		for i := 1; i <= 4; i++ {
+			n := i
+			units <- func(_ context.Context) (int, error) {
+				return n * n, nil // compute some value
+			}
+		}
+	}, func(_ context.Context, results map[uint64]int, errs map[uint64]error) error {
+		if len(errs) > 0 {
+			return fmt.Errorf("%d units failed", len(errs))
+		}
+		sum := 0
+		for _, result := range results {
+			sum += result
+		}
+		fmt.Println(fmt.Sprintf("sum of squares: %d", sum))
+		return nil
+	}))
+	_ = p.RunWithContext(context.Background())
+	// Output: sum of squares: 30
+}
@@ -0,0 +1,17 @@
+package pipeline
+
+// Freeze prevents further modification of the Pipeline, e.g. to catch bugs where a shared pipeline is accidentally altered.
+// Once frozen, the methods that add, insert or remove steps, or that change the hooks, finalizers, middleware, options or event channel, panic.
+// Running the pipeline remains allowed.
+// A frozen pipeline can't be unfrozen, but Pipeline.Clone returns a copy that isn't frozen.
+func (p *Pipeline[T]) Freeze() *Pipeline[T] {
+	p.frozen = true
+	return p
+}
+
+// mustNotBeFrozen panics if the pipeline has been frozen with Freeze.
+func (p *Pipeline[T]) mustNotBeFrozen() {
+	if p.frozen {
+		panic("pipeline is frozen")
+	}
+}
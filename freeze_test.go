@@ -0,0 +1,37 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline_Freeze(t *testing.T) {
+	var calls int
+	p := NewPipeline[context.Context]().AddStepFromFunc("step", func(_ context.Context) error {
+		calls++
+		return nil
+	}).Freeze()
+
+	assert.PanicsWithValue(t, "pipeline is frozen", func() {
+		p.AddStep(NewStep("another", failingAction))
+	})
+	assert.PanicsWithValue(t, "pipeline is frozen", func() {
+		p.WithSteps()
+	})
+	assert.PanicsWithValue(t, "pipeline is frozen", func() {
+		p.WithOptions(Options{})
+	})
+	assert.PanicsWithValue(t, "pipeline is frozen", func() {
+		p.RemoveStep("step")
+	})
+	assert.Equal(t, 1, p.Len())
+
+	err := p.RunWithContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	clone := p.Clone().AddStep(NewStep("another", failingAction))
+	assert.Equal(t, 2, clone.Len(), "clone isn't frozen")
+}
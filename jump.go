@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultMaxJumps is the maximum number of jumps within a single pipeline run if Options.MaxJumps is zero.
+const DefaultMaxJumps = 100
+
+// jumpSignal is the error returned by the action of a step created with Jump.
+// It is intercepted by the pipeline and isn't considered a failure.
+type jumpSignal struct {
+	target string
+}
+
+// Error implements error.
+func (j *jumpSignal) Error() string {
+	return fmt.Sprintf("jump to step %q", j.target)
+}
+
+/*
+Jump returns a step that redirects the pipeline to the first step with the given name, e.g. to retry a sequence of steps from a checkpoint.
+The pipeline continues with the target step, which can be before or after the jump step.
+Use Step.When to jump conditionally, otherwise a backward jump loops until Options.MaxJumps is exceeded, in which case the pipeline fails.
+
+The step's error handler and the after hooks receive the jump signal as error, but the step isn't counted as failed.
+If no step with the given name exists, the jump step fails.
+Jumps are only supported in Pipeline.RunWithContext and its variants.
+In deferred steps or with Pipeline.RunDAG, the jump step fails.
+*/
+func Jump[T context.Context](targetName string) Step[T] {
+	step := NewStep[T]("jump to "+targetName, func(_ T) error {
+		return &jumpSignal{target: targetName}
+	})
+	step.jumpTarget = targetName
+	return step
+}
+
+// jumpTarget returns the index of the step that the given result of step jumps to.
+// It returns false if step hasn't been created with Jump or if the result isn't a jump signal.
+// Jump signals that step merely wraps, e.g. the error of a nested pipeline, are not considered.
+func (p *Pipeline[T]) jumpTarget(step Step[T], result Result) (int, bool) {
+	var jump *jumpSignal
+	if step.jumpTarget == "" || !errors.As(result, &jump) {
+		return 0, false
+	}
+	for i, step := range p.steps {
+		if step.Name == jump.target && !step.deferred {
+			return i, true
+		}
+	}
+	return -1, true
+}
+
+// maxJumps returns Options.MaxJumps or DefaultMaxJumps if unset.
+func (o Options) maxJumps() int {
+	if o.MaxJumps == 0 {
+		return DefaultMaxJumps
+	}
+	return o.MaxJumps
+}
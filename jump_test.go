@@ -0,0 +1,82 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJump(t *testing.T) {
+	t.Run("GivenForwardJump_ThenSkipSteps", func(t *testing.T) {
+		var calls []string
+		p := NewPipeline[context.Context]()
+		p.WithSteps(
+			p.NewStep("first", recordCall(&calls, "first")),
+			Jump[context.Context]("last"),
+			p.NewStep("skipped", recordCall(&calls, "skipped")),
+			p.NewStep("last", recordCall(&calls, "last")),
+		)
+		summary, err := p.RunWithSummary(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"first", "last"}, calls)
+		assert.Equal(t, 0, summary.Failed)
+	})
+	t.Run("GivenBackwardJump_ThenRunStepAgain", func(t *testing.T) {
+		var calls []string
+		p := NewPipeline[context.Context]()
+		p.WithSteps(
+			p.NewStep("checkpoint", recordCall(&calls, "checkpoint")),
+			p.NewStep("work", recordCall(&calls, "work")),
+			Jump[context.Context]("checkpoint").When(func(_ context.Context) bool {
+				return len(calls) < 4
+			}),
+			p.NewStep("last", recordCall(&calls, "last")),
+		)
+		err := p.RunWithContext(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"checkpoint", "work", "checkpoint", "work", "last"}, calls)
+	})
+	t.Run("GivenEndlessLoop_WhenMaxJumpsExceeded_ThenReturnError", func(t *testing.T) {
+		var calls []string
+		p := NewPipeline[context.Context]().WithOptions(Options{MaxJumps: 2})
+		p.WithSteps(
+			p.NewStep("loop", recordCall(&calls, "loop")),
+			Jump[context.Context]("loop"),
+		)
+		err := p.RunWithContext(context.Background())
+		assert.EqualError(t, err, "step 'jump to loop' failed: exceeded maximum of 2 jumps")
+		assert.Equal(t, []string{"loop", "loop", "loop"}, calls)
+	})
+	t.Run("GivenUnknownTarget_ThenReturnError", func(t *testing.T) {
+		p := NewPipeline[context.Context]()
+		p.WithSteps(Jump[context.Context]("unknown"))
+		summary, err := p.RunWithSummary(context.Background())
+		assert.EqualError(t, err, `step 'jump to unknown' failed: unknown jump target "unknown"`)
+		assert.Equal(t, 1, summary.Failed)
+	})
+	t.Run("GivenJumpInNestedPipeline_ThenDontJumpInParent", func(t *testing.T) {
+		var calls []string
+		inner := NewPipeline[context.Context]()
+		inner.WithSteps(
+			inner.NewStep("target", recordCall(&calls, "inner target")),
+			Defer(Jump[context.Context]("target")),
+		)
+		p := NewPipeline[context.Context]()
+		p.WithSteps(
+			p.NewStep("target", recordCall(&calls, "outer target")),
+			inner.AsNestedStep("nested"),
+			p.NewStep("after", recordCall(&calls, "after")),
+		)
+		err := p.RunWithContext(context.Background())
+		assert.EqualError(t, err, `step 'nested' failed: step 'jump to target' failed: jump to step "target"`)
+		assert.Equal(t, []string{"outer target", "inner target"}, calls)
+	})
+}
+
+func recordCall(calls *[]string, name string) ActionFunc[context.Context] {
+	return func(_ context.Context) error {
+		*calls = append(*calls, name)
+		return nil
+	}
+}
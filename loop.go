@@ -0,0 +1,82 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrMaxIterations is returned by loop steps like While and Until if the maximum number of iterations has been reached.
+var ErrMaxIterations = errors.New("maximum iterations reached")
+
+// ItemError is the error that ForEach returns if the action failed for an item.
+// A Pipeline reports it as "step '<name>' item <index> failed: <err>".
+type ItemError struct {
+	// Index is the zero-based index of the item.
+	Index int
+	// Err is the error returned by the action.
+	Err error
+}
+
+// Error returns the index and the error message of the failed item.
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("item %d failed: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error returned by the action.
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// ForEach returns a new Step that invokes the given action once per item, sequentially in the order of the items.
+// The items are retrieved when the step runs, so that the slice can be computed from the state of previous steps.
+// The step fails fast on the first error returned by the action, wrapped in ItemError with the zero-based index of the item.
+func ForEach[T context.Context, I any](name string, items func(ctx T) []I, action func(ctx T, item I) error) Step[T] {
+	return NewStep[T](name, func(ctx T) error {
+		for i, item := range items(ctx) {
+			if err := action(ctx, item); err != nil {
+				return &ItemError{Index: i, Err: err}
+			}
+		}
+		return nil
+	})
+}
+
+// While returns a new Step that repeatedly runs the given action as long as the condition evaluates to true.
+// The condition is evaluated before each iteration, so the action may not run at all.
+// The context is checked before each iteration, and the loop returns the context's error if it is canceled.
+// An error returned by the action aborts the loop immediately.
+// The optional maxIterations caps the number of iterations, e.g. to prevent infinite loops in tests.
+// If it is given and greater than zero, the loop fails with ErrMaxIterations instead of running the action more often than that.
+// If it is omitted or zero, the loop is unbounded.
+// It panics if more than one maxIterations is given.
+func While[T context.Context](name string, condition Predicate[T], action ActionFunc[T], maxIterations ...int) Step[T] {
+	if len(maxIterations) > 1 {
+		panic("at most one maxIterations can be given")
+	}
+	limit := 0
+	if len(maxIterations) == 1 {
+		limit = maxIterations[0]
+	}
+	return NewStep[T](name, func(ctx T) error {
+		for i := 0; ; i++ {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !condition(ctx) {
+				return nil
+			}
+			if limit > 0 && i >= limit {
+				return fmt.Errorf("%w: %d", ErrMaxIterations, limit)
+			}
+			if err := action(ctx); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// Until is While, but it runs the action as long as the condition evaluates to false.
+func Until[T context.Context](name string, condition Predicate[T], action ActionFunc[T], maxIterations ...int) Step[T] {
+	return While[T](name, Not[T](condition), action, maxIterations...)
+}
@@ -0,0 +1,172 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEach(t *testing.T) {
+	tests := map[string]struct {
+		givenItems    []string
+		givenFailItem string
+		expectedItems []string
+		expectedError string
+	}{
+		"GivenNoItems_ThenDoNothing": {
+			givenItems:    nil,
+			expectedItems: nil,
+		},
+		"GivenItems_ThenIterateInOrder": {
+			givenItems:    []string{"a", "b", "c"},
+			expectedItems: []string{"a", "b", "c"},
+		},
+		"GivenFailingItem_ThenFailFast": {
+			givenItems:    []string{"a", "b", "c"},
+			givenFailItem: "b",
+			expectedItems: []string{"a", "b"},
+			expectedError: "step 'iterate' item 1 failed: b failed",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var visited []string
+			p := NewPipeline[context.Context]()
+			p.AddStep(ForEach("iterate", func(_ context.Context) []string {
+				return tc.givenItems
+			}, func(_ context.Context, item string) error {
+				visited = append(visited, item)
+				if item == tc.givenFailItem {
+					return errors.New(item + " failed")
+				}
+				return nil
+			}))
+			err := p.RunWithContext(context.Background())
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				var itemErr *ItemError
+				require.ErrorAs(t, err, &itemErr)
+				assert.Equal(t, len(tc.expectedItems)-1, itemErr.Index)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedItems, visited)
+		})
+	}
+}
+
+func TestWhile(t *testing.T) {
+	tests := map[string]struct {
+		givenLimit     int
+		givenMax       int
+		givenActionErr error
+		expectedCalls  int
+		expectedError  string
+	}{
+		"GivenConditionFalse_ThenDontRunAction": {
+			givenLimit:    0,
+			expectedCalls: 0,
+		},
+		"GivenCondition_ThenRunUntilFalse": {
+			givenLimit:    3,
+			expectedCalls: 3,
+		},
+		"GivenMaxIterations_WhenExceeded_ThenReturnError": {
+			givenLimit:    5,
+			givenMax:      3,
+			expectedCalls: 3,
+			expectedError: "step 'loop' failed: maximum iterations reached: 3",
+		},
+		"GivenMaxIterations_WhenNotExceeded_ThenReturnNil": {
+			givenLimit:    3,
+			givenMax:      3,
+			expectedCalls: 3,
+		},
+		"GivenFailingAction_ThenAbortImmediately": {
+			givenLimit:     3,
+			givenActionErr: errors.New("failed"),
+			expectedCalls:  1,
+			expectedError:  "step 'loop' failed: failed",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			p := NewPipeline[context.Context]()
+			p.AddStep(While("loop", func(_ context.Context) bool {
+				return calls < tc.givenLimit
+			}, func(_ context.Context) error {
+				calls++
+				return tc.givenActionErr
+			}, tc.givenMax))
+			err := p.RunWithContext(context.Background())
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
+
+func TestWhile_Cancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	step := While("loop", Bool[context.Context](true), func(_ context.Context) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	err := step.Action(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, step.Action(ctx), context.DeadlineExceeded, "already canceled")
+}
+
+func TestUntil(t *testing.T) {
+	calls := 0
+	step := Until("poll", func(_ context.Context) bool {
+		return calls == 3
+	}, func(_ context.Context) error {
+		calls++
+		return nil
+	}, 10)
+	err := step.Action(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.ErrorIs(t, Until("poll", Bool[context.Context](false), func(_ context.Context) error { return nil }, 2).Action(context.Background()), ErrMaxIterations)
+}
+
+func TestWhile_TooManyMaxIterations(t *testing.T) {
+	assert.Panics(t, func() {
+		While("loop", Bool[context.Context](true), func(_ context.Context) error { return nil }, 1, 2)
+	})
+}
+
+func ExampleForEach() {
+	type exampleContext struct {
+		context.Context
+		files []string
+	}
+
+	p := NewPipeline[*exampleContext]()
+	p.WithSteps(
+		p.NewStep("list files", func(ctx *exampleContext) error {
+			ctx.files = []string{"a.txt", "b.txt"}
+			return nil
+		}),
+		ForEach("print files", func(ctx *exampleContext) []string {
+			return ctx.files
+		}, func(_ *exampleContext, file string) error {
+			fmt.Println(file)
+			return nil
+		}),
+	)
+	_ = p.RunWithContext(&exampleContext{Context: context.Background()})
+	// Output: a.txt
+	// b.txt
+}
@@ -0,0 +1,74 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// MapReduce returns a new Step that maps each item concurrently with the given mapper and then reduces the mapped results with the given reducer.
+// The items are retrieved when the step runs, and they are mapped in a pool of a number of Go routines indicated by concurrency.
+// The reducer receives the mapped results in the order of the items.
+// If any mapper fails, the reducer is not called and the step fails with the errors of all failed items, each wrapped with the zero-based index of the item.
+// If concurrency is 0 or less, the function panics.
+func MapReduce[T context.Context, I, R any](name string, items func(ctx T) []I, mapper func(ctx T, item I) (R, error), reducer func(ctx T, results []R) error, concurrency int) Step[T] {
+	return MapReduceWithOptions[T, I, R](name, items, mapper, reducer, concurrency, ParallelOptions{})
+}
+
+// MapReduceWithOptions is MapReduce, but the step's behaviour can be altered with ParallelOptions.
+// With ParallelOptions.FailFast, the remaining items are not mapped anymore once a mapper fails, and the context given to the running mappers is canceled.
+// The same applies with ParallelOptions.MaxErrors once the given number of mappers have failed.
+// If a mapper panics, the panic is recovered and converted into an error that unwraps to ErrPanic.
+// ParallelOptions.GracePeriod is not supported, the step always waits for the running mappers to return.
+func MapReduceWithOptions[T context.Context, I, R any](name string, items func(ctx T) []I, mapper func(ctx T, item I) (R, error), reducer func(ctx T, results []R) error, concurrency int, options ParallelOptions) Step[T] {
+	if concurrency < 1 {
+		panic("concurrency cannot be lower than 1")
+	}
+	return NewStep[T](name, func(ctx T) error {
+		list := items(ctx)
+		results := make([]R, len(list))
+		errs := make([]error, len(list))
+		runner := newChildRunner(ctx, options)
+		defer runner.close()
+
+		indexChan := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < concurrency; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range indexChan {
+					n := i
+					errs[n] = runner.run(func(ctx T) error {
+						result, err := mapper(ctx, list[n])
+						results[n] = result
+						return err
+					})
+				}
+			}()
+		}
+	supply:
+		for i := range list {
+			select {
+			case <-runner.ctx.Done():
+				break supply
+			case indexChan <- i:
+			}
+		}
+		close(indexChan)
+		wg.Wait()
+
+		var failed []error
+		for i, err := range errs {
+			if err != nil {
+				failed = append(failed, fmt.Errorf("item %d failed: %w", i, err))
+			}
+		}
+		err := errors.Join(failed...)
+		if ctx.Err() != nil || err != nil {
+			return setResultErrorFromContext(ctx, name, err)
+		}
+		return reducer(ctx, results)
+	})
+}
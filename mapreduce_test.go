@@ -0,0 +1,125 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestMapReduce(t *testing.T) {
+	numbers := func(_ context.Context) []int {
+		return []int{5, 4, 3, 2, 1}
+	}
+	tests := map[string]struct {
+		givenMapper     func(ctx context.Context, item int) (int, error)
+		givenOptions    ParallelOptions
+		expectedResults []int
+		expectedError   string
+	}{
+		"GivenSuccessfulMapper_ThenReduceInItemOrder": {
+			givenMapper: func(_ context.Context, item int) (int, error) {
+				time.Sleep(time.Duration(item) * time.Millisecond) // let later items finish first
+				return item * 10, nil
+			},
+			expectedResults: []int{50, 40, 30, 20, 10},
+		},
+		"GivenFailingMapper_ThenDontReduce": {
+			givenMapper: func(_ context.Context, item int) (int, error) {
+				if item%2 == 0 {
+					return 0, fmt.Errorf("%d is even", item)
+				}
+				return item, nil
+			},
+			expectedError: "item 1 failed: 4 is even\nitem 3 failed: 2 is even",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+			var reduced []int
+			step := MapReduceWithOptions("map-reduce", numbers, tc.givenMapper, func(_ context.Context, results []int) error {
+				reduced = results
+				return nil
+			}, 2, tc.givenOptions)
+			err := step.Action(context.Background())
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedResults, reduced)
+		})
+	}
+}
+
+func TestMapReduceWithOptions_FailFast(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	mapped := 0
+	step := MapReduceWithOptions("map-reduce", func(_ context.Context) []int {
+		return []int{0, 1, 2, 3, 4, 5}
+	}, func(ctx context.Context, item int) (int, error) {
+		if item == 0 {
+			return 0, errors.New("boom")
+		}
+		mapped++ // the pool size of 1 runs the mappers in sequence
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}, func(_ context.Context, _ []int) error {
+		require.Fail(t, "reducer should not be called")
+		return nil
+	}, 1, ParallelOptions{FailFast: true})
+	err := step.Action(context.Background())
+	assert.ErrorContains(t, err, "item 0 failed: boom")
+	assert.LessOrEqual(t, mapped, 1, "remaining items are not mapped")
+}
+
+func TestMapReduce_Cancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	step := MapReduce("map-reduce", func(_ context.Context) []int {
+		return []int{1, 2, 3}
+	}, func(ctx context.Context, item int) (int, error) {
+		cancel()
+		return item, nil
+	}, func(_ context.Context, _ []int) error {
+		require.Fail(t, "reducer should not be called")
+		return nil
+	}, 1)
+	err := step.Action(ctx)
+	assert.EqualError(t, err, "context canceled")
+}
+
+func TestMapReduce_InvalidConcurrency(t *testing.T) {
+	assert.PanicsWithValue(t, "concurrency cannot be lower than 1", func() {
+		MapReduce[context.Context, int, int]("map-reduce", nil, nil, nil, 0)
+	})
+}
+
+func ExampleMapReduce() {
+	p := NewPipeline[context.Context]()
+	p.AddStep(MapReduce("count words", func(_ context.Context) []string {
+		return []string{"the quick brown fox", "jumps over", "the lazy dog"}
+	}, func(_ context.Context, line string) (int, error) {
+		return len(strings.Fields(line)), nil
+	}, func(_ context.Context, counts []int) error {
+		total := 0
+		for i, count := range counts {
+			fmt.Println(fmt.Sprintf("line %d: %d words", i+1, count))
+			total += count
+		}
+		fmt.Println(fmt.Sprintf("total: %d words", total))
+		return nil
+	}, 2))
+	_ = p.RunWithContext(context.Background())
+	// Output: line 1: 4 words
+	// line 2: 2 words
+	// line 3: 3 words
+	// total: 9 words
+}
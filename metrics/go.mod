@@ -0,0 +1,26 @@
+module github.com/ccremer/go-command-pipeline/metrics
+
+go 1.23
+
+require (
+	github.com/ccremer/go-command-pipeline v0.21.0
+	github.com/prometheus/client_golang v1.19.1
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/prometheus/client_model v0.5.0 // indirect
+	github.com/prometheus/common v0.48.0 // indirect
+	github.com/prometheus/procfs v0.12.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The replace directive makes the module build against the core module of this repository during development.
+// It is ignored when the module is required by other modules, which then get the required version above.
+replace github.com/ccremer/go-command-pipeline => ../
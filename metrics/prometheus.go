@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// StatusSuccess is the value of the status label for steps that returned no error.
+	StatusSuccess = "success"
+	// StatusError is the value of the status label for steps that returned an error.
+	StatusError = "error"
+	// StatusCanceled is the value of the status label for steps that returned context.Canceled or context.DeadlineExceeded.
+	StatusCanceled = "canceled"
+)
+
+// Options configures the collectors of NewPrometheusMiddleware.
+type Options struct {
+	// Namespace is prepended to the metric names, e.g. "myapp" results in "myapp_steps_total".
+	// Use it to avoid collisions with other metrics in a shared registerer.
+	Namespace string
+	// ConstLabels are added to every metric, e.g. to distinguish multiple pipelines that share a registerer.
+	ConstLabels prometheus.Labels
+}
+
+// NewPrometheusMiddleware returns a middleware that collects metrics of each step and registers the collectors with the given registerer.
+// Add it with pipeline.Pipeline.WithStepAwareMiddleware.
+//
+// The following metrics are collected:
+//   - steps_total{step,status}: A counter of the finished steps, where status is one of StatusSuccess, StatusError or StatusCanceled.
+//   - step_duration_seconds{step}: A histogram of the duration of the steps' actions.
+//
+// The metric names are prefixed with Options.Namespace, if set.
+// Since the collectors can only be registered once per registerer, this function panics if it is called repeatedly with the same registerer, unless the Options differ in Namespace or ConstLabels.
+// Use the same middleware in all pipelines that should be counted together instead, which is also safe for pipelines that run concurrently.
+func NewPrometheusMiddleware[T context.Context](registerer prometheus.Registerer, opts Options) pipeline.StepAwareMiddleware[T] {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   opts.Namespace,
+		Name:        "steps_total",
+		Help:        "Total number of finished pipeline steps.",
+		ConstLabels: opts.ConstLabels,
+	}, []string{"step", "status"})
+	histogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace:   opts.Namespace,
+		Name:        "step_duration_seconds",
+		Help:        "Duration of pipeline steps in seconds.",
+		ConstLabels: opts.ConstLabels,
+		Buckets:     prometheus.DefBuckets,
+	}, []string{"step"})
+	registerer.MustRegister(counter, histogram)
+
+	return func(step pipeline.Step[T], next pipeline.ActionFunc[T]) pipeline.ActionFunc[T] {
+		return func(ctx T) error {
+			start := time.Now()
+			err := next(ctx)
+			histogram.WithLabelValues(step.Name).Observe(time.Since(start).Seconds())
+			counter.WithLabelValues(step.Name, status(err)).Inc()
+			return err
+		}
+	}
+}
+
+// status returns the value of the status label for the given error.
+func status(err error) string {
+	switch {
+	case err == nil:
+		return StatusSuccess
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return StatusCanceled
+	default:
+		return StatusError
+	}
+}
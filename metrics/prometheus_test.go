@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPrometheusMiddleware(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	p := pipeline.NewPipeline[context.Context]().WithStepAwareMiddleware(NewPrometheusMiddleware[context.Context](registry, Options{}))
+	p.WithSteps(
+		p.NewStep("first", func(_ context.Context) error {
+			return nil
+		}),
+		p.NewStep("second", func(_ context.Context) error {
+			return nil
+		}),
+	)
+	require.NoError(t, p.RunWithContext(context.Background()))
+	require.NoError(t, p.RunWithContext(context.Background()))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	counts := map[string]float64{}
+	durations := map[string]uint64{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			switch family.GetName() {
+			case "steps_total":
+				counts[labels["step"]+"/"+labels["status"]] = metric.GetCounter().GetValue()
+			case "step_duration_seconds":
+				durations[labels["step"]] = metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{"first/success": 2, "second/success": 2}, counts)
+	assert.Equal(t, map[string]uint64{"first": 2, "second": 2}, durations)
+}
+
+func TestNewPrometheusMiddleware_Status(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	p := pipeline.NewPipeline[context.Context]().WithStepAwareMiddleware(NewPrometheusMiddleware[context.Context](registry, Options{}))
+	_ = p.WithSteps(p.NewStep("failing", func(_ context.Context) error {
+		return errors.New("failed")
+	})).RunWithContext(context.Background())
+	_ = p.WithSteps(p.NewStep("canceled", func(_ context.Context) error {
+		return context.Canceled
+	})).RunWithContext(context.Background())
+
+	err := testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP steps_total Total number of finished pipeline steps.
+# TYPE steps_total counter
+steps_total{status="canceled",step="canceled"} 1
+steps_total{status="error",step="failing"} 1
+`), "steps_total")
+	assert.NoError(t, err)
+}
+
+func TestNewPrometheusMiddleware_RegisterTwice(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	NewPrometheusMiddleware[context.Context](registry, Options{})
+	assert.Panics(t, func() {
+		NewPrometheusMiddleware[context.Context](registry, Options{})
+	})
+}
+
+func TestNewPrometheusMiddleware_Options(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	first := pipeline.NewPipeline[context.Context]().WithStepAwareMiddleware(NewPrometheusMiddleware[context.Context](registry, Options{
+		Namespace:   "app",
+		ConstLabels: prometheus.Labels{"pipeline": "first"},
+	}))
+	second := pipeline.NewPipeline[context.Context]().WithStepAwareMiddleware(NewPrometheusMiddleware[context.Context](registry, Options{
+		Namespace:   "app",
+		ConstLabels: prometheus.Labels{"pipeline": "second"},
+	}))
+	require.NoError(t, first.AddStepFromFunc("step", func(_ context.Context) error {
+		return nil
+	}).RunWithContext(context.Background()))
+	require.Error(t, second.AddStepFromFunc("step", func(_ context.Context) error {
+		return errors.New("failed")
+	}).RunWithContext(context.Background()))
+
+	err := testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP app_steps_total Total number of finished pipeline steps.
+# TYPE app_steps_total counter
+app_steps_total{pipeline="first",status="success",step="step"} 1
+app_steps_total{pipeline="second",status="error",step="step"} 1
+`), "app_steps_total")
+	assert.NoError(t, err)
+}
+
+func TestNewPrometheusMiddleware_Concurrent(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	instrument := NewPrometheusMiddleware[context.Context](registry, Options{})
+	slow := pipeline.NewPipeline[context.Context]().WithStepAwareMiddleware(instrument)
+	slow.AddStepFromFunc("slow", func(_ context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+	fast := pipeline.NewPipeline[context.Context]().WithStepAwareMiddleware(instrument)
+	fast.AddStepFromFunc("fast", func(_ context.Context) error {
+		return nil
+	})
+	p := pipeline.NewPipeline[context.Context]()
+	p.WithSteps(pipeline.NewFanOutStep[context.Context]("fan-out", pipeline.SupplierFromSlice([]*pipeline.Pipeline[context.Context]{slow, fast}), nil))
+	require.NoError(t, p.RunWithContext(context.Background()))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	sums := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != "step_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "step" {
+					sums[label.GetValue()] = metric.GetHistogram().GetSampleSum()
+				}
+			}
+		}
+	}
+	require.Len(t, sums, 2)
+	assert.GreaterOrEqual(t, sums["slow"], 0.05)
+	assert.Less(t, sums["fast"], 0.05)
+}
@@ -0,0 +1,73 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+)
+
+// StepMiddleware wraps the ActionFunc of a step, e.g. to add cross-cutting behaviour like logging, timing or retries to every step.
+// It returns an ActionFunc that is expected to call next, unless the middleware decides to short-circuit the step.
+type StepMiddleware[T context.Context] func(next ActionFunc[T]) ActionFunc[T]
+
+// StepAwareMiddleware is a StepMiddleware that also gets the step whose action it wraps, e.g. to label logs, metrics or spans with Step.Name.
+type StepAwareMiddleware[T context.Context] func(step Step[T], next ActionFunc[T]) ActionFunc[T]
+
+// WithStepMiddleware appends the given middleware to the Pipeline and returns itself.
+// Each middleware is applied around the Step.Action of every step in this pipeline, including the steps nested with WithNestedSteps.
+// A pipeline converted with AsNestedStep applies its own middleware to its steps.
+// The middleware registered first is the outermost, i.e. it is invoked first and returns last.
+// Step.Handler and the hooks are not affected by middleware, they get the error that the outermost middleware returned.
+func (p *Pipeline[T]) WithStepMiddleware(middleware ...StepMiddleware[T]) *Pipeline[T] {
+	p.mustNotBeFrozen()
+	for _, mw := range middleware {
+		p.middleware = append(p.middleware, func(_ Step[T], next ActionFunc[T]) ActionFunc[T] {
+			return mw(next)
+		})
+	}
+	return p
+}
+
+// WithStepAwareMiddleware appends the given middleware to the Pipeline and returns itself.
+// It behaves like WithStepMiddleware and shares the same order, i.e. the middleware are applied in the order they were registered, regardless of their kind.
+func (p *Pipeline[T]) WithStepAwareMiddleware(middleware ...StepAwareMiddleware[T]) *Pipeline[T] {
+	p.mustNotBeFrozen()
+	p.middleware = append(p.middleware, middleware...)
+	return p
+}
+
+// applyMiddleware returns the action of the given step wrapped with all middleware of the pipeline.
+func (p *Pipeline[T]) applyMiddleware(step Step[T]) ActionFunc[T] {
+	action := step.Action
+	for i := len(p.middleware) - 1; i >= 0; i-- {
+		action = p.middleware[i](step, action)
+	}
+	return action
+}
+
+// TimingMiddleware returns a StepMiddleware that measures how long each step's action takes.
+// The given observer is called after the action has returned, with the action's duration and error.
+func TimingMiddleware[T context.Context](observer func(ctx T, duration time.Duration, err error)) StepMiddleware[T] {
+	return func(next ActionFunc[T]) ActionFunc[T] {
+		return func(ctx T) error {
+			start := time.Now()
+			err := next(ctx)
+			observer(ctx, time.Since(start), err)
+			return err
+		}
+	}
+}
+
+// RecoverMiddleware returns a StepMiddleware that recovers from panics in each step's action and returns them as PanicError instead.
+// It's similar to Options.RecoverPanics, but since it's a middleware, the recovered error can be observed by middleware registered before it.
+func RecoverMiddleware[T context.Context]() StepMiddleware[T] {
+	return func(next ActionFunc[T]) ActionFunc[T] {
+		return func(ctx T) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = newPanicError(r)
+				}
+			}()
+			return next(ctx)
+		}
+	}
+}
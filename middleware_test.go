@@ -0,0 +1,146 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline_WithStepMiddleware(t *testing.T) {
+	var calls []string
+	trace := func(name string) StepMiddleware[context.Context] {
+		return func(next ActionFunc[context.Context]) ActionFunc[context.Context] {
+			return func(ctx context.Context) error {
+				calls = append(calls, name+" before")
+				err := next(ctx)
+				calls = append(calls, name+" after")
+				return err
+			}
+		}
+	}
+	p := NewPipeline[context.Context]().
+		WithStepMiddleware(trace("outer")).
+		WithStepMiddleware(trace("inner"))
+	p.WithSteps(
+		p.NewStep("first", func(_ context.Context) error {
+			calls = append(calls, "first")
+			return nil
+		}),
+		p.NewStep("second", func(_ context.Context) error {
+			calls = append(calls, "second")
+			return nil
+		}),
+	)
+	err := p.RunWithContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"outer before", "inner before", "first", "inner after", "outer after",
+		"outer before", "inner before", "second", "inner after", "outer after",
+	}, calls)
+}
+
+func TestPipeline_WithStepMiddleware_NestedSteps(t *testing.T) {
+	var calls []string
+	trace := func(name string) StepMiddleware[context.Context] {
+		return func(next ActionFunc[context.Context]) ActionFunc[context.Context] {
+			return func(ctx context.Context) error {
+				calls = append(calls, name)
+				return next(ctx)
+			}
+		}
+	}
+	inner := NewPipeline[context.Context]().WithStepMiddleware(trace("inner"))
+	inner.AddStepFromFunc("converted", succeedingAction)
+	p := NewPipeline[context.Context]().WithStepMiddleware(trace("outer"))
+	p.WithSteps(
+		p.WithNestedSteps("nested", nil, p.NewStep("child", succeedingAction)),
+		inner.AsNestedStep("as nested"),
+	)
+	err := p.RunWithContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer", "outer", "outer", "inner"}, calls)
+}
+
+func TestPipeline_WithStepAwareMiddleware(t *testing.T) {
+	var calls []string
+	named := func(step Step[context.Context], next ActionFunc[context.Context]) ActionFunc[context.Context] {
+		return func(ctx context.Context) error {
+			calls = append(calls, "named "+step.Name)
+			return next(ctx)
+		}
+	}
+	plain := func(next ActionFunc[context.Context]) ActionFunc[context.Context] {
+		return func(ctx context.Context) error {
+			calls = append(calls, "plain")
+			return next(ctx)
+		}
+	}
+	p := NewPipeline[context.Context]().
+		WithStepAwareMiddleware(named).
+		WithStepMiddleware(plain)
+	p.WithSteps(
+		p.NewStep("first", succeedingAction),
+		p.NewStep("second", succeedingAction),
+	)
+	err := p.RunWithContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"named first", "plain", "named second", "plain"}, calls)
+}
+
+func TestTimingMiddleware(t *testing.T) {
+	var durations []time.Duration
+	var errs []error
+	p := NewPipeline[context.Context]().WithStepMiddleware(TimingMiddleware(func(_ context.Context, duration time.Duration, err error) {
+		durations = append(durations, duration)
+		errs = append(errs, err)
+	}))
+	p.WithSteps(
+		p.NewStep("sleep", sleepUntilDone(10*time.Millisecond)),
+		p.NewStep("fail", failingAction),
+	)
+	err := p.RunWithContext(context.Background())
+	assert.Error(t, err)
+	require.Len(t, durations, 2)
+	assert.GreaterOrEqual(t, durations[0], 10*time.Millisecond)
+	assert.NoError(t, errs[0])
+	assert.Error(t, errs[1])
+}
+
+func TestRecoverMiddleware(t *testing.T) {
+	var observed error
+	p := NewPipeline[context.Context]().WithStepMiddleware(
+		TimingMiddleware(func(_ context.Context, _ time.Duration, err error) {
+			observed = err
+		}),
+		RecoverMiddleware[context.Context](),
+	)
+	p.AddStepFromFunc("panic", func(_ context.Context) error {
+		panic(errors.New("boom"))
+	})
+	err := p.RunWithContext(context.Background())
+	assert.EqualError(t, err, "step 'panic' panicked: boom")
+	assert.ErrorIs(t, err, ErrPanic)
+	assert.ErrorIs(t, observed, ErrPanic, "outer middleware observes the recovered panic")
+}
+
+func ExamplePipeline_WithStepMiddleware() {
+	logging := func(next ActionFunc[context.Context]) ActionFunc[context.Context] {
+		return func(ctx context.Context) error {
+			fmt.Println("step started")
+			return next(ctx)
+		}
+	}
+	p := NewPipeline[context.Context]().WithStepMiddleware(logging)
+	p.AddStepFromFunc("hello", func(_ context.Context) error {
+		fmt.Println("hello world")
+		return nil
+	})
+	_ = p.RunWithContext(context.Background())
+	// Output: step started
+	// hello world
+}
@@ -1,11 +1,66 @@
 package pipeline
 
+import (
+	"context"
+	"time"
+)
+
 // Options configures the given Pipeline with a behaviour-altering settings.
 type Options struct {
 	// DisableErrorWrapping disables the wrapping of errors that are emitted from pipeline steps.
 	// This effectively causes error to be exactly the error as returned from a step.
 	// The step's name is omitted from the error message.
 	DisableErrorWrapping bool
+	// ContinueOnError runs all steps even if some of them fail.
+	// The errors of the failed steps are collected in a MultiError, which is returned at the end wrapped in a Result that has an empty name.
+	// The step's error handler is called as usual, and the finalizer receives the aggregated error.
+	// If the context is canceled, the pipeline still aborts, but the collected errors are returned along with the context's error.
+	ContinueOnError bool
+	// AbortOn is a list of errors that stop the pipeline gracefully like ErrAbort, e.g. to stop if there is nothing left to do.
+	// If a step returns an error that matches any of them with errors.Is, the remaining steps don't run and the pipeline returns nil.
+	AbortOn []error
+	// MaxJumps limits the number of jumps with Jump steps within a single run, to prevent infinite loops.
+	// Once exceeded, the jump step fails.
+	// If zero, DefaultMaxJumps applies.
+	MaxJumps int
+	// CancellationSkipsQuietly alters the behaviour when the context is canceled during a pipeline run.
+	// By default, the next step in the execution order fails with the context's error.
+	// When enabled, the remaining steps are skipped instead, which is reported to the listeners registered with Pipeline.WithSkippedHooks.
+	// The pipeline returns the context's error wrapped in a Result that has an empty name, since no step has actually failed.
+	CancellationSkipsQuietly bool
+	// DefaultStepTimeout is the timeout applied to every step that has no Step.Timeout set.
+	// Each step's action is given a derived context that is canceled after the timeout, the parent context remains untouched.
+	// If T is not context.Context, it has to implement ContextDeriver, otherwise the step panics.
+	// If zero (default), steps have no timeout.
+	DefaultStepTimeout time.Duration
+	// RecoverPanics recovers panics that occur in a step's action and converts them into a PanicError.
+	// The error returned by the pipeline reads like "step '<name>' panicked: <value>" and unwraps to ErrPanic.
+	// The step's error handler and the pipeline's finalizer are called with that error as usual.
+	// If false (default), panics are propagated.
+	RecoverPanics bool
+	// BlockOnEvents makes the Pipeline wait until each Event is received from the channel set with Pipeline.WithEventChannel.
+	// If false (default), events are dropped if the channel is full.
+	BlockOnEvents bool
+	// AutoMutableContext sets up the context given to Pipeline.RunWithContext with MutableContext, if it isn't already.
+	// This is the same as creating the Pipeline with NewMutablePipeline.
+	// If T is not context.Context, it has to implement ContextDeriver, otherwise running the pipeline panics.
+	AutoMutableContext bool
+	// PerStepContext gives each step's action its own context derived from the pipeline's context, e.g. to store scratch data that is scoped to a single step.
+	// The derived context is set up with MutableContext and initialized with a copy of the values stored in the pipeline's context, if any.
+	// Values stored with StoreInContext within a step are thus visible only in that step, but not in the following steps or in the pipeline's context.
+	// Note that this prevents passing values from one step to the next via the context.
+	// It is intended for T being context.Context, otherwise T has to implement ContextDeriver, or the step panics.
+	PerStepContext bool
+	// ValidateBeforeRun calls Pipeline.Validate before the steps run.
+	// If the validation fails, the validation error is returned without running any step or finalizer.
+	ValidateBeforeRun bool
+	// RequireUniqueStepNames makes Pipeline.Validate report duplicate step names.
+	RequireUniqueStepNames bool
+	// Configure is an optional func that is called at the beginning of each Pipeline.RunWithContext.
+	// It can alter the given options based on the context, e.g. to enable certain behaviour when a flag is set in the context.
+	// The altered options are only effective for the current run, the Pipeline's options remain unchanged.
+	// The given context is the one passed to Pipeline.RunWithContext.
+	Configure func(ctx context.Context, options *Options)
 }
 
 // WithOptions configures the Pipeline with settings.
@@ -13,6 +68,15 @@ type Options struct {
 // Options are applied to nested pipelines provided they are set before building the nested pipeline.
 // Nested pipelines can be configured with their own Options.
 func (p *Pipeline[T]) WithOptions(options Options) *Pipeline[T] {
+	p.mustNotBeFrozen()
 	p.options = options
 	return p
 }
+
+// forRun returns a copy of the options that are altered by Options.Configure, if any.
+func (o Options) forRun(ctx context.Context) Options {
+	if o.Configure != nil {
+		o.Configure(ctx, &o)
+	}
+	return o
+}
@@ -3,7 +3,9 @@ package pipeline
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,3 +25,353 @@ func TestPipeline_WithOptions(t *testing.T) {
 		assert.Equal(t, "some error", err.Error())
 	})
 }
+
+func TestOptions_Configure(t *testing.T) {
+	type debugKey struct{}
+	p := NewPipeline[context.Context]().WithOptions(Options{
+		Configure: func(ctx context.Context, options *Options) {
+			if ctx.Value(debugKey{}) == true {
+				options.DisableErrorWrapping = true
+			}
+		},
+	})
+	p.AddStepFromFunc("configured", func(_ context.Context) error {
+		return errors.New("some error")
+	})
+
+	err := p.RunWithContext(context.WithValue(context.Background(), debugKey{}, true))
+	assert.EqualError(t, err, "some error", "flag enables option")
+
+	err = p.RunWithContext(context.Background())
+	assert.EqualError(t, err, "step 'configured' failed: some error", "option only valid for flagged run")
+	assert.False(t, p.options.DisableErrorWrapping)
+}
+
+func TestOptions_CancellationSkipsQuietly(t *testing.T) {
+	tests := map[string]struct {
+		givenOption      bool
+		expectedSkipped  []string
+		expectedError    string
+		expectedStepName string
+	}{
+		"GivenOptionEnabled_WhenCanceled_ThenSkipRemainingSteps": {
+			givenOption:      true,
+			expectedSkipped:  []string{"second", "third"},
+			expectedError:    "context canceled",
+			expectedStepName: "",
+		},
+		"GivenOptionDisabled_WhenCanceled_ThenFailNextStep": {
+			givenOption:      false,
+			expectedSkipped:  nil,
+			expectedError:    "step 'second' failed: context canceled",
+			expectedStepName: "second",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var skipped []string
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			p := NewPipeline[context.Context]().WithOptions(Options{CancellationSkipsQuietly: tc.givenOption})
+			p.WithSkippedHooks(func(step Step[context.Context], reason string) {
+				assert.Equal(t, "context canceled", reason)
+				skipped = append(skipped, step.Name)
+			})
+			p.WithSteps(
+				p.NewStep("first", func(_ context.Context) error {
+					cancel()
+					return nil
+				}),
+				p.NewStep("second", func(_ context.Context) error {
+					t.Fail()
+					return nil
+				}),
+				p.NewStep("third", func(_ context.Context) error {
+					t.Fail()
+					return nil
+				}),
+			)
+			err := p.RunWithContext(ctx)
+			require.Error(t, err)
+			assert.EqualError(t, err, tc.expectedError)
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.Equal(t, tc.expectedStepName, err.(Result).Name())
+			assert.Equal(t, tc.expectedSkipped, skipped)
+		})
+	}
+}
+
+func TestOptions_CancellationSkipsQuietly_Deadline(t *testing.T) {
+	var reasons []string
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	p := NewPipeline[context.Context]().WithOptions(Options{CancellationSkipsQuietly: true})
+	p.WithSkippedHooks(func(_ Step[context.Context], reason string) {
+		reasons = append(reasons, reason)
+	})
+	p.AddStepFromFunc("step", func(_ context.Context) error {
+		return nil
+	})
+	err := p.RunWithContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []string{"context deadline exceeded"}, reasons)
+}
+
+func TestOptions_DefaultStepTimeout(t *testing.T) {
+	waitForDeadline := func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return errors.New("step has not been bounded")
+		}
+	}
+	t.Run("GivenDefaultTimeout_ThenBoundEachStep", func(t *testing.T) {
+		var handlerCalls int
+		p := NewPipeline[context.Context]().WithOptions(Options{DefaultStepTimeout: 10 * time.Millisecond})
+		tolerate := func(_ context.Context, err error) error {
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			handlerCalls++
+			return nil
+		}
+		p.WithSteps(
+			p.NewStep("first", waitForDeadline).WithErrorHandler(tolerate),
+			p.NewStep("second", waitForDeadline).WithErrorHandler(tolerate),
+		)
+		err := p.RunWithContext(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, handlerCalls)
+	})
+	t.Run("GivenStepTimeout_ThenOverrideDefaultTimeout", func(t *testing.T) {
+		p := NewPipeline[context.Context]().WithOptions(Options{DefaultStepTimeout: time.Second})
+		p.WithSteps(
+			p.NewStep("explicit", func(ctx context.Context) error {
+				deadline, ok := ctx.Deadline()
+				require.True(t, ok)
+				assert.WithinDuration(t, time.Now().Add(10*time.Millisecond), deadline, 10*time.Millisecond)
+				return waitForDeadline(ctx)
+			}).WithTimeout(10 * time.Millisecond),
+		)
+		start := time.Now()
+		err := p.RunWithContext(context.Background())
+		assert.EqualError(t, err, "step 'explicit' failed: context deadline exceeded")
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+	t.Run("GivenNegativeStepTimeout_ThenDisableDefaultTimeout", func(t *testing.T) {
+		p := NewPipeline[context.Context]().WithOptions(Options{DefaultStepTimeout: time.Millisecond})
+		p.AddStep(p.NewStep("unbounded", func(ctx context.Context) error {
+			_, ok := ctx.Deadline()
+			assert.False(t, ok)
+			return nil
+		}).WithTimeout(-1))
+		err := p.RunWithContext(context.Background())
+		require.NoError(t, err)
+	})
+	t.Run("GivenContextDeriver_ThenBoundStep", func(t *testing.T) {
+		p := NewPipeline[*derivableContext]().WithOptions(Options{DefaultStepTimeout: 10 * time.Millisecond})
+		p.AddStepFromFunc("derived", func(ctx *derivableContext) error {
+			assert.Equal(t, "value", ctx.field)
+			return waitForDeadline(ctx)
+		})
+		err := p.RunWithContext(&derivableContext{Context: context.Background(), field: "value"})
+		assert.EqualError(t, err, "step 'derived' failed: context deadline exceeded")
+	})
+}
+
+func TestOptions_RecoverPanics(t *testing.T) {
+	t.Run("GivenPanic_ThenReturnErrPanic", func(t *testing.T) {
+		var finalizerErr error
+		p := NewPipeline[context.Context]().WithOptions(Options{RecoverPanics: true})
+		p.WithSteps(
+			p.NewStep("panicking", func(_ context.Context) error {
+				panic("boom")
+			}),
+			p.NewStep("not running", func(_ context.Context) error {
+				t.Fail()
+				return nil
+			}),
+		).WithFinalizer(func(_ context.Context, err error) error {
+			finalizerErr = err
+			return err
+		})
+		err := p.RunWithContext(context.Background())
+		assert.EqualError(t, err, "step 'panicking' panicked: boom")
+		assert.ErrorIs(t, err, ErrPanic)
+		assert.Equal(t, err, finalizerErr, "finalizer called with error")
+		var panicErr *PanicError
+		require.True(t, errors.As(err, &panicErr))
+		assert.Equal(t, "boom", panicErr.Value)
+		assert.Contains(t, string(panicErr.Stack), "TestOptions_RecoverPanics")
+		assert.Equal(t, "panicking", err.(Result).Name())
+	})
+	t.Run("GivenPanicWithError_ThenUnwrapToError", func(t *testing.T) {
+		errSentinel := errors.New("sentinel")
+		p := NewPipeline[context.Context]().WithOptions(Options{RecoverPanics: true})
+		p.AddStepFromFunc("panicking", func(_ context.Context) error {
+			panic(errSentinel)
+		})
+		err := p.RunWithContext(context.Background())
+		assert.ErrorIs(t, err, ErrPanic)
+		assert.ErrorIs(t, err, errSentinel)
+	})
+	t.Run("GivenOptionDisabled_ThenPropagatePanic", func(t *testing.T) {
+		p := NewPipeline[context.Context]()
+		p.AddStepFromFunc("panicking", func(_ context.Context) error {
+			panic("boom")
+		})
+		assert.PanicsWithValue(t, "boom", func() {
+			_ = p.RunWithContext(context.Background())
+		})
+	})
+}
+
+func TestOptions_ContinueOnError(t *testing.T) {
+	errSentinel := errors.New("sentinel")
+	succeed := func(_ context.Context) error { return nil }
+	fail := func(_ context.Context) error { return errSentinel }
+	tests := map[string]struct {
+		givenActions        []ActionFunc[context.Context]
+		expectedFailedSteps []string
+		expectedError       string
+	}{
+		"GivenAllStepsFail_ThenAggregateAllErrors": {
+			givenActions:        []ActionFunc[context.Context]{fail, fail, fail},
+			expectedFailedSteps: []string{"step 0", "step 1", "step 2"},
+			expectedError:       "step 'step 0' failed: sentinel\nstep 'step 1' failed: sentinel\nstep 'step 2' failed: sentinel",
+		},
+		"GivenSomeStepsFail_ThenAggregateFailedSteps": {
+			givenActions:        []ActionFunc[context.Context]{succeed, fail, succeed},
+			expectedFailedSteps: []string{"step 1"},
+			expectedError:       "step 'step 1' failed: sentinel",
+		},
+		"GivenNoStepsFail_ThenReturnNil": {
+			givenActions: []ActionFunc[context.Context]{succeed, succeed, succeed},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			var finalizerErr error
+			p := NewPipeline[context.Context]().WithOptions(Options{ContinueOnError: true})
+			for i, action := range tc.givenActions {
+				a := action
+				p.AddStep(p.NewStep(fmt.Sprintf("step %d", i), func(ctx context.Context) error {
+					calls++
+					return a(ctx)
+				}))
+			}
+			p.WithFinalizer(func(_ context.Context, err error) error {
+				finalizerErr = err
+				return err
+			})
+			err := p.RunWithContext(context.Background())
+			assert.Equal(t, len(tc.givenActions), calls, "all steps run")
+			assert.Equal(t, err, finalizerErr, "finalizer receives aggregate")
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.expectedError)
+			assert.ErrorIs(t, err, errSentinel)
+			var multiErr *MultiError
+			require.True(t, errors.As(err, &multiErr))
+			assert.Equal(t, tc.expectedFailedSteps, multiErr.FailedStepNames())
+		})
+	}
+}
+
+func TestOptions_ContinueOnError_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := NewPipeline[context.Context]().WithOptions(Options{ContinueOnError: true})
+	p.WithSteps(
+		p.NewStep("failing", func(_ context.Context) error {
+			cancel()
+			return errors.New("failed")
+		}),
+		p.NewStep("canceled", failingAction),
+	)
+	err := p.RunWithContext(ctx)
+	var multiErr *MultiError
+	require.True(t, errors.As(err, &multiErr))
+	assert.Equal(t, []string{"failing", "canceled"}, multiErr.FailedStepNames())
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestOptions_AutoMutableContext(t *testing.T) {
+	t.Run("GivenContext_ThenStoreValues", func(t *testing.T) {
+		p := NewPipeline[context.Context]().WithOptions(Options{AutoMutableContext: true})
+		p.WithSteps(
+			p.NewStep("store", func(ctx context.Context) error {
+				StoreInContext(ctx, "key", "value")
+				return nil
+			}),
+			p.NewStep("load", func(ctx context.Context) error {
+				assert.Equal(t, "value", MustLoadFromContext(ctx, "key"))
+				return nil
+			}),
+		)
+		assert.NoError(t, p.RunWithContext(context.Background()))
+	})
+	t.Run("GivenCustomContext_ThenDeriveContext", func(t *testing.T) {
+		p := NewPipeline[*derivableContext]().WithOptions(Options{AutoMutableContext: true})
+		p.AddStepFromFunc("store", func(ctx *derivableContext) error {
+			StoreInContext(ctx, "key", ctx.field)
+			return nil
+		})
+		assert.NoError(t, p.RunWithContext(&derivableContext{Context: context.Background(), field: "value"}))
+	})
+	t.Run("GivenMutableContext_ThenKeepContext", func(t *testing.T) {
+		ctx := MutableContext(context.Background())
+		p := NewPipeline[context.Context]().WithOptions(Options{AutoMutableContext: true})
+		p.AddStepFromFunc("store", func(ctx context.Context) error {
+			StoreInContext(ctx, "key", "value")
+			return nil
+		})
+		assert.NoError(t, p.RunWithContext(ctx))
+		assert.Equal(t, "value", MustLoadFromContext(ctx, "key"), "value stored in given context")
+	})
+}
+
+func TestOptions_PerStepContext(t *testing.T) {
+	ctx := MutableContext(context.Background())
+	StoreInContext(ctx, "shared", "value")
+	p := NewPipeline[context.Context]().WithOptions(Options{PerStepContext: true})
+	p.WithSteps(
+		p.NewStep("store", func(ctx context.Context) error {
+			assert.Equal(t, "value", MustLoadFromContext(ctx, "shared"), "values of pipeline context are visible")
+			StoreInContext(ctx, "scratch", "data")
+			return nil
+		}),
+		p.NewStep("load", func(ctx context.Context) error {
+			_, found := LoadFromContext(ctx, "scratch")
+			assert.False(t, found, "value of previous step is not visible")
+			return nil
+		}),
+	)
+	assert.NoError(t, p.RunWithContext(ctx))
+	_, found := LoadFromContext(ctx, "scratch")
+	assert.False(t, found, "value is not visible in pipeline context")
+
+	p = NewPipeline[context.Context]().WithOptions(Options{PerStepContext: true})
+	p.AddStepFromFunc("store", func(ctx context.Context) error {
+		StoreInContext(ctx, "scratch", "data")
+		return nil
+	})
+	assert.NoError(t, p.RunWithContext(context.Background()), "immutable context")
+}
+
+func TestOptions_ValidateBeforeRun(t *testing.T) {
+	called := false
+	p := NewPipeline[context.Context]().WithOptions(Options{ValidateBeforeRun: true})
+	p.WithSteps(
+		p.NewStep("first", func(_ context.Context) error {
+			called = true
+			return nil
+		}),
+		Step[context.Context]{Name: "no action"},
+	)
+	err := p.RunWithContext(context.Background())
+	assert.EqualError(t, err, `step "no action" at index 1 has no action`)
+	assert.False(t, called, "no step should run")
+}
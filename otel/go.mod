@@ -0,0 +1,25 @@
+module github.com/ccremer/go-command-pipeline/otel
+
+go 1.23
+
+require (
+	github.com/ccremer/go-command-pipeline v0.21.0
+	github.com/stretchr/testify v1.8.4
+	go.opentelemetry.io/otel v1.24.0
+	go.opentelemetry.io/otel/sdk v1.24.0
+	go.opentelemetry.io/otel/trace v1.24.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel/metric v1.24.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The replace directive makes the module build against the core module of this repository during development.
+// It is ignored when the module is required by other modules, which then get the required version above.
+replace github.com/ccremer/go-command-pipeline => ../
@@ -0,0 +1,49 @@
+package otel
+
+import (
+	"context"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewTracingMiddleware returns a middleware that creates a span for each step using the given tracer.
+// Add it with pipeline.Pipeline.WithStepAwareMiddleware.
+//
+// The span is named after the step and started just before the step's action runs.
+// Its parent is the span in the context given to the action, e.g. the span in the context passed to pipeline.Pipeline.RunWithContext.
+// It ends after the action has returned, with the error recorded and the span status set to codes.Error if the step failed.
+//
+// The action gets a context with the step's span, so that spans started in the action, and the spans of steps nested with pipeline.Pipeline.WithNestedSteps, have the step's span as parent.
+// This also holds for steps that run concurrently, e.g. in pipeline.NewFanOutStep, provided the child pipelines use the middleware as well.
+// If T is a custom context type, it has to implement pipeline.ContextDeriver to pass the span to the action.
+// Otherwise, the action gets the original context and nested spans have the same parent as the step's span.
+func NewTracingMiddleware[T context.Context](tracer trace.Tracer) pipeline.StepAwareMiddleware[T] {
+	return func(step pipeline.Step[T], next pipeline.ActionFunc[T]) pipeline.ActionFunc[T] {
+		return func(ctx T) error {
+			spanCtx, span := tracer.Start(ctx, step.Name)
+			defer span.End()
+			err := next(withSpan(ctx, spanCtx))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			} else {
+				span.SetStatus(codes.Ok, "")
+			}
+			return err
+		}
+	}
+}
+
+// withSpan returns spanCtx as T, either directly if T is context.Context, or by calling pipeline.ContextDeriver.DeriveContext on parent.
+// It returns parent if neither is possible.
+func withSpan[T context.Context](parent T, spanCtx context.Context) T {
+	if derived, ok := spanCtx.(T); ok {
+		return derived
+	}
+	if deriver, ok := any(parent).(pipeline.ContextDeriver[T]); ok {
+		return deriver.DeriveContext(spanCtx)
+	}
+	return parent
+}
@@ -0,0 +1,95 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNewTracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := provider.Tracer("test")
+
+	p := pipeline.NewPipeline[context.Context]().WithStepAwareMiddleware(NewTracingMiddleware[context.Context](tracer))
+	p.WithSteps(
+		p.NewStep("first", func(_ context.Context) error {
+			return nil
+		}),
+		p.WithNestedSteps("nested", nil, p.NewStep("inner", func(_ context.Context) error {
+			return nil
+		})),
+		p.NewStep("failing", func(_ context.Context) error {
+			return errors.New("failed")
+		}),
+	)
+	ctx, root := tracer.Start(context.Background(), "root")
+	err := p.RunWithContext(ctx)
+	root.End()
+	require.EqualError(t, err, "step 'failing' failed: failed")
+
+	names := spansByName(t, recorder, 5)
+	require.Contains(t, names, "first")
+	require.Contains(t, names, "nested")
+	require.Contains(t, names, "inner")
+	require.Contains(t, names, "failing")
+
+	assert.Equal(t, codes.Ok, names["first"].Status().Code)
+	assert.Equal(t, root.SpanContext().SpanID(), names["first"].Parent().SpanID(), "top-level step parent")
+	assert.Equal(t, root.SpanContext().TraceID(), names["first"].SpanContext().TraceID(), "same trace")
+	assert.Equal(t, names["nested"].SpanContext().SpanID(), names["inner"].Parent().SpanID(), "nested step parent")
+	assert.Equal(t, root.SpanContext().TraceID(), names["inner"].SpanContext().TraceID(), "same trace")
+	assert.Equal(t, codes.Error, names["failing"].Status().Code)
+	assert.Equal(t, "failed", names["failing"].Status().Description)
+	require.Len(t, names["failing"].Events(), 1, "recorded error")
+}
+
+func TestNewTracingMiddleware_Concurrent(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracing := NewTracingMiddleware[context.Context](provider.Tracer("test"))
+
+	var children []*pipeline.Pipeline[context.Context]
+	for i, d := range []time.Duration{30 * time.Millisecond, 0, 15 * time.Millisecond} {
+		child := pipeline.NewPipeline[context.Context]().WithStepAwareMiddleware(tracing)
+		child.WithSteps(child.WithNestedSteps(fmt.Sprintf("child %d", i), nil, child.NewStep(fmt.Sprintf("inner %d", i), func(_ context.Context) error {
+			time.Sleep(d)
+			return nil
+		})))
+		children = append(children, child)
+	}
+	p := pipeline.NewPipeline[context.Context]().WithStepAwareMiddleware(tracing)
+	p.WithSteps(pipeline.NewFanOutStep[context.Context]("fan-out", pipeline.SupplierFromSlice(children), nil))
+	err := p.RunWithContext(context.Background())
+	require.NoError(t, err)
+
+	names := spansByName(t, recorder, 7)
+	require.Contains(t, names, "fan-out")
+	assert.False(t, names["fan-out"].Parent().IsValid(), "top-level step has no parent")
+	for i := range children {
+		child, inner := fmt.Sprintf("child %d", i), fmt.Sprintf("inner %d", i)
+		require.Contains(t, names, child)
+		require.Contains(t, names, inner)
+		assert.Equal(t, names["fan-out"].SpanContext().SpanID(), names[child].Parent().SpanID(), "child parent")
+		assert.Equal(t, names[child].SpanContext().SpanID(), names[inner].Parent().SpanID(), "inner parent")
+	}
+}
+
+func spansByName(t *testing.T, recorder *tracetest.SpanRecorder, count int) map[string]sdktrace.ReadOnlySpan {
+	spans := recorder.Ended()
+	require.Len(t, spans, count)
+	names := make(map[string]sdktrace.ReadOnlySpan, len(spans))
+	for _, span := range spans {
+		names[span.Name()] = span
+	}
+	return names
+}
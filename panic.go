@@ -0,0 +1,37 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrPanic is the sentinel error that recovered panics unwrap to, see PanicError.
+var ErrPanic = errors.New("panic")
+
+// PanicError is an error that has been converted from a recovered panic.
+// It unwraps to ErrPanic, and to the recovered value if it is an error.
+type PanicError struct {
+	// Value is the value that has been recovered.
+	Value any
+	// Stack is the stack trace of the Go routine that panicked.
+	Stack []byte
+}
+
+// newPanicError returns a new PanicError with the stack trace of the current Go routine.
+func newPanicError(value any) *PanicError {
+	return &PanicError{Value: value, Stack: debug.Stack()}
+}
+
+// Error returns the recovered value formatted with "%+v", e.g. to include stack traces of wrapped errors.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panicked: %+v", e.Value)
+}
+
+// Unwrap returns ErrPanic and the recovered value if it is an error.
+func (e *PanicError) Unwrap() []error {
+	if err, ok := e.Value.(error); ok {
+		return []error{ErrPanic, err}
+	}
+	return []error{ErrPanic}
+}
@@ -0,0 +1,125 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrAbandoned is the error that parallel steps put into the results for child pipelines that were still running when ParallelOptions.GracePeriod expired.
+var ErrAbandoned = errors.New("pipeline abandoned after grace period")
+
+// ErrSiblingFailed is the error that parallel steps wrap the results with of child pipelines that have been canceled because of ParallelOptions.FailFast or ParallelOptions.MaxErrors.
+var ErrSiblingFailed = errors.New("canceled due to failed sibling pipeline")
+
+// ParallelOptions configures the behaviour of parallel steps like NewFanOutStepWithOptions and NewWorkerPoolStepWithOptions.
+type ParallelOptions struct {
+	// GracePeriod is the duration a parallel step waits for still running child pipelines after the context has been canceled.
+	// Once the grace period expires, the step stops waiting and the results of the remaining child pipelines are set to ErrAbandoned.
+	// The abandoned child pipelines are not terminated, their Go routines keep running until they return.
+	// Supplied child pipelines that haven't been started yet are not started anymore.
+	// If zero (default), the step waits indefinitely for all child pipelines to finish.
+	GracePeriod time.Duration
+	// FailFast cancels the context given to the Supplier and the child pipelines as soon as a child pipeline returns an error.
+	// The ParallelResultHandler still receives the results of all child pipelines that have been run.
+	// The result of the first failed child pipeline is left as-is, while the results of the child pipelines that failed due to the cancellation are wrapped in ErrSiblingFailed.
+	// This option requires T to implement ContextDeriver, unless T is context.Context itself.
+	FailFast bool
+	// MaxErrors is like FailFast, but the context is only canceled once the given number of child pipelines have returned an error, e.g. to tolerate a few failures in batch jobs.
+	// The results of the child pipelines that failed due to the cancellation are wrapped in ErrSiblingFailed.
+	// If FailFast is enabled, the context is canceled on the first error regardless of MaxErrors.
+	// If zero (default), the context isn't canceled due to failed child pipelines.
+	MaxErrors int
+}
+
+// childRunner runs the child pipelines of parallel steps.
+type childRunner[T context.Context] struct {
+	parent    T
+	ctx       T
+	cancel    context.CancelFunc
+	maxErrors int64
+	failures  atomic.Int64
+}
+
+// newChildRunner returns a new childRunner.
+// If fail-fast or an error threshold is enabled, the child pipelines run with a context derived from ctx, which gets canceled once the threshold of errors is reached.
+// The returned runner has to be closed in any case.
+func newChildRunner[T context.Context](ctx T, options ParallelOptions) *childRunner[T] {
+	maxErrors := int64(options.MaxErrors)
+	if options.FailFast {
+		maxErrors = 1
+	}
+	if maxErrors <= 0 {
+		return &childRunner[T]{parent: ctx, ctx: ctx, cancel: func() {}}
+	}
+	childCtx, cancel := context.WithCancel(ctx)
+	return &childRunner[T]{parent: ctx, ctx: deriveContext(ctx, childCtx), cancel: cancel, maxErrors: maxErrors}
+}
+
+// run runs the given func (e.g. Pipeline.RunWithContext) and cancels the remaining children if the child's error reaches the error threshold.
+// A panic in fn is recovered and returned as Result that unwraps to ErrPanic, so that a single panicking child doesn't take down the whole step.
+func (r *childRunner[T]) run(fn func(ctx T) error) error {
+	err := runRecovered(r.ctx, fn)
+	if err == nil || r.maxErrors == 0 {
+		return err
+	}
+	failures := r.failures.Add(1)
+	if failures == r.maxErrors {
+		r.cancel()
+		return err
+	}
+	if failures > r.maxErrors && r.parent.Err() == nil && errors.Is(err, context.Canceled) {
+		return fmt.Errorf("%w: %w", ErrSiblingFailed, err)
+	}
+	return err
+}
+
+// runRecovered runs fn and converts a panic into a Result with an empty name that wraps a PanicError.
+func runRecovered[T context.Context](ctx T, fn func(ctx T) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = newResult("", fmt.Errorf("child %w", newPanicError(r)), 0)
+		}
+	}()
+	return fn(ctx)
+}
+
+// close releases the resources of the derived context.
+func (r *childRunner[T]) close() {
+	r.cancel()
+}
+
+// waitForChildren waits until all child pipelines are done.
+// If the grace period is set and the context is canceled, it stops waiting after the grace period and marks all unfinished pipelines as abandoned.
+// If abandon is non-nil, it is called before the pipelines are marked, so that pipelines that haven't been started yet can be prevented from starting after the step returned.
+func waitForChildren(ctx context.Context, wg *sync.WaitGroup, options ParallelOptions, count *uint64, m *sync.Map, abandon func()) {
+	if options.GracePeriod <= 0 {
+		wg.Wait()
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return
+	case <-ctx.Done():
+	}
+	timer := time.NewTimer(options.GracePeriod)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		if abandon != nil {
+			abandon()
+		}
+		for n := uint64(0); n < atomic.LoadUint64(count); n++ {
+			m.LoadOrStore(n, ParallelResult{Index: n, Err: ErrAbandoned})
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+)
+
+type pipeValueKey struct{}
+
+// PipeStep returns a new Step that forms a typed data pipeline, where each step's output is the next step's input.
+// The current value is carried in the context, hence the context has to be set up with MutableContext first.
+// Use StorePipeValue to set the initial input and LoadPipeValue to retrieve the final output after running the pipeline.
+//
+// The step fails if there is no current value or if it is not of type In.
+// If fn returns an error, the current value remains unchanged.
+func PipeStep[T context.Context, In, Out any](name string, fn func(ctx T, in In) (Out, error)) Step[T] {
+	return NewStep[T](name, func(ctx T) error {
+		value, found := LoadFromContext(ctx, pipeValueKey{})
+		if !found {
+			return fmt.Errorf("no pipe value found in context")
+		}
+		in, ok := value.(In)
+		if !ok && value != nil {
+			return fmt.Errorf("pipe value is of type %T, but %T is required", value, in)
+		}
+		out, err := fn(ctx, in)
+		if err != nil {
+			return err
+		}
+		StorePipeValue(ctx, out)
+		return nil
+	})
+}
+
+// StorePipeValue sets the current value of a typed data pipeline built with PipeStep.
+//
+// Note: This method is thread-safe, but panics if ctx has not been set up with MutableContext first.
+func StorePipeValue(ctx context.Context, value any) {
+	StoreInContext(ctx, pipeValueKey{}, value)
+}
+
+// LoadPipeValue returns the current value of a typed data pipeline built with PipeStep.
+// It returns the zero value and false if there is no current value or if it is not of type V.
+//
+// Note: This method is thread-safe, but panics if ctx has not been set up with MutableContext first.
+func LoadPipeValue[V any](ctx context.Context) (V, bool) {
+	return LoadTyped[V](ctx, pipeValueKey{})
+}
@@ -0,0 +1,93 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeStep(t *testing.T) {
+	tests := map[string]struct {
+		givenInput        any
+		givenStrconv      func(ctx context.Context, in string) (int, error)
+		expectedValue     int
+		expectErrorString string
+	}{
+		"GivenIntInput_WhenChaining_ThenReturnFinalValue": {
+			givenInput:    21,
+			givenStrconv:  atoi,
+			expectedValue: 2121,
+		},
+		"GivenFailingStep_WhenChaining_ThenAbortWithError": {
+			givenInput: 21,
+			givenStrconv: func(_ context.Context, _ string) (int, error) {
+				return 0, errors.New("conversion failed")
+			},
+			expectErrorString: "step 'atoi' failed: conversion failed",
+		},
+		"GivenWrongInputType_WhenChaining_ThenReturnError": {
+			givenInput:        "21",
+			givenStrconv:      atoi,
+			expectErrorString: "step 'itoa' failed: pipe value is of type string, but int is required",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := MutableContext(context.Background())
+			StorePipeValue(ctx, tc.givenInput)
+			p := NewPipeline[context.Context]().WithSteps(
+				PipeStep("itoa", func(_ context.Context, in int) (string, error) {
+					return strconv.Itoa(in), nil
+				}),
+				PipeStep("duplicate", func(_ context.Context, in string) (string, error) {
+					return in + in, nil
+				}),
+				PipeStep("atoi", tc.givenStrconv),
+			)
+			err := p.RunWithContext(ctx)
+			if tc.expectErrorString != "" {
+				require.Error(t, err)
+				assert.EqualError(t, err, tc.expectErrorString)
+				return
+			}
+			require.NoError(t, err)
+			result, found := LoadPipeValue[int](ctx)
+			assert.True(t, found)
+			assert.Equal(t, tc.expectedValue, result)
+		})
+	}
+}
+
+func TestPipeStep_NoValue(t *testing.T) {
+	ctx := MutableContext(context.Background())
+	err := PipeStep("step", atoi).Action(ctx)
+	assert.EqualError(t, err, "no pipe value found in context")
+	_, found := LoadPipeValue[int](ctx)
+	assert.False(t, found)
+}
+
+func atoi(_ context.Context, in string) (int, error) {
+	return strconv.Atoi(in)
+}
+
+func ExamplePipeStep() {
+	ctx := MutableContext(context.Background())
+	StorePipeValue(ctx, 42)
+	p := NewPipeline[context.Context]().WithSteps(
+		PipeStep("double", func(_ context.Context, in int) (int, error) {
+			return in * 2, nil
+		}),
+		PipeStep("format", func(_ context.Context, in int) (string, error) {
+			return fmt.Sprintf("result: %d", in), nil
+		}),
+	)
+	_ = p.RunWithContext(ctx)
+	result, _ := LoadPipeValue[string](ctx)
+	fmt.Println(result)
+	// Output: result: 84
+}
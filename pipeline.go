@@ -3,78 +3,282 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
 )
 
 // Pipeline holds and runs intermediate actions, called "steps".
 type Pipeline[T context.Context] struct {
-	steps       []Step[T]
-	beforeHooks []Listener[T]
-	finalizer   ErrorHandler[T]
-	options     Options
+	steps           []Step[T]
+	beforeHooks     []Listener[T]
+	afterHooks      []ResultListener[T]
+	skippedHooks    []SkippedListener[T]
+	finalizers      []ErrorHandler[T]
+	resultFinalizer FinalizerFunc[T]
+	options         Options
+	events          chan<- Event
+	middleware      []StepAwareMiddleware[T]
+	counter         *stepCounter
+
+	mutableContext bool
+	frozen         bool
+	// isNested is true for the pipelines run by AsNestedStep and WithNestedSteps, which return ErrSkipRemaining as Result of the skipping step.
+	isNested bool
 }
 
 // Listener is a simple func that listens to Pipeline events.
 type Listener[T context.Context] func(step Step[T])
 
+// ResultListener is a simple func that listens to Pipeline events of steps that have finished.
+// The error is the one returned by the step's action, after it has been handled by the step's ErrorHandler (if any).
+type ResultListener[T context.Context] func(step Step[T], err error)
+
+// SkippedListener is a simple func that listens to Pipeline events of steps that have been skipped.
+// The reason describes why the step has been skipped.
+type SkippedListener[T context.Context] func(step Step[T], reason string)
+
 // ActionFunc is the func that contains your business logic.
 type ActionFunc[T context.Context] func(ctx T) error
 
 // ErrorHandler is a func that gets called when a step's ActionFunc has finished with an error.
 type ErrorHandler[T context.Context] func(ctx T, err error) error
 
+// FinalizerFunc is a func that gets called after a pipeline has finished.
+// The given Result is nil if the pipeline was successful, otherwise Result.Name gives direct access to the step that failed.
+type FinalizerFunc[T context.Context] func(ctx T, result Result)
+
 // NewPipeline returns a new Pipeline instance.
 func NewPipeline[T context.Context]() *Pipeline[T] {
 	return &Pipeline[T]{}
 }
 
+// NewMutablePipeline returns a new Pipeline instance that makes sure the context is set up with MutableContext.
+// The context given to RunWithContext is wrapped with MutableContext automatically if it isn't already, so that StoreInContext and LoadFromContext work in every step.
+// If T is not context.Context, it has to implement ContextDeriver, otherwise RunWithContext panics.
+func NewMutablePipeline[T context.Context]() *Pipeline[T] {
+	return &Pipeline[T]{mutableContext: true}
+}
+
 // WithBeforeHooks takes a list of listeners.
 // Each Listener is called once in the given order just before the ActionFunc is invoked.
 // The listeners should return as fast as possible, as they are not intended to do actual business logic.
 func (p *Pipeline[T]) WithBeforeHooks(listeners ...Listener[T]) *Pipeline[T] {
+	p.mustNotBeFrozen()
 	p.beforeHooks = listeners
 	return p
 }
 
+// WithAfterHooks takes a list of listeners.
+// Each ResultListener is called once in the given order just after the ActionFunc and the step's ErrorHandler (if any) have returned.
+// The listeners are also called if the step failed, before the pipeline aborts.
+// They are not called for steps that are skipped or that don't run due to a canceled context.
+// The listeners should return as fast as possible, as they are not intended to do actual business logic.
+func (p *Pipeline[T]) WithAfterHooks(listeners ...ResultListener[T]) *Pipeline[T] {
+	p.mustNotBeFrozen()
+	p.afterHooks = listeners
+	return p
+}
+
+// WithDependencyRecorder adds the given DependencyRecorder to the before hooks, so that each step is recorded before it runs.
+// It is also added to the after hooks, so that the duration of each step is recorded, and to the skipped hooks, so that skipped steps are recorded separately.
+// This is a shortcut for adding DependencyRecorder.Record to WithBeforeHooks, DependencyRecorder.RecordResult to WithAfterHooks and DependencyRecorder.RecordSkipped to WithSkippedHooks.
+// Since WithBeforeHooks, WithAfterHooks and WithSkippedHooks replace all listeners, call WithDependencyRecorder afterwards if they are used.
+func (p *Pipeline[T]) WithDependencyRecorder(recorder *DependencyRecorder[T]) *Pipeline[T] {
+	p.mustNotBeFrozen()
+	p.beforeHooks = append(p.beforeHooks, recorder.Record)
+	p.afterHooks = append(p.afterHooks, recorder.RecordResult)
+	p.skippedHooks = append(p.skippedHooks, recorder.RecordSkipped)
+	return p
+}
+
+// WithProgressReporter adds ProgressReporter.RecordResult to the after hooks and ProgressReporter.RecordSkipped to the skipped hooks.
+// Since WithAfterHooks and WithSkippedHooks replace all listeners, call WithProgressReporter afterwards if they are used.
+func (p *Pipeline[T]) WithProgressReporter(reporter *ProgressReporter[T]) *Pipeline[T] {
+	p.mustNotBeFrozen()
+	p.afterHooks = append(p.afterHooks, reporter.RecordResult)
+	p.skippedHooks = append(p.skippedHooks, reporter.RecordSkipped)
+	return p
+}
+
+// WithSkippedHooks takes a list of listeners.
+// Each SkippedListener is called once in the given order for each step that doesn't run, either because its Step.Condition evaluated to false,
+// or because the pipeline has been canceled and Options.CancellationSkipsQuietly is enabled.
+// The listeners should return as fast as possible, as they are not intended to do actual business logic.
+func (p *Pipeline[T]) WithSkippedHooks(listeners ...SkippedListener[T]) *Pipeline[T] {
+	p.mustNotBeFrozen()
+	p.skippedHooks = listeners
+	return p
+}
+
 // AddStep appends the given step to the Pipeline at the end and returns itself.
 func (p *Pipeline[T]) AddStep(step Step[T]) *Pipeline[T] {
+	p.mustNotBeFrozen()
 	p.steps = append(p.steps, step)
 	return p
 }
 
+// PrependStep inserts the given step into the Pipeline at the beginning and returns itself.
+// This is useful to add a setup step to a pipeline that has already been built.
+func (p *Pipeline[T]) PrependStep(step Step[T]) *Pipeline[T] {
+	return p.InsertStep(0, step)
+}
+
+// InsertStep inserts the given step into the Pipeline at the given zero-based index and returns itself.
+// The steps from the index onwards are shifted back.
+// An index equal to the number of steps appends the step at the end, similar to AddStep.
+// It panics if the index is negative or greater than the number of steps.
+func (p *Pipeline[T]) InsertStep(index int, step Step[T]) *Pipeline[T] {
+	p.mustNotBeFrozen()
+	if index < 0 || index > len(p.steps) {
+		panic(fmt.Errorf("step index %d out of range [0, %d]", index, len(p.steps)))
+	}
+	p.steps = append(p.steps, Step[T]{})
+	copy(p.steps[index+1:], p.steps[index:])
+	p.steps[index] = step
+	return p
+}
+
+// RemoveStep removes the first step whose Step.Name equals the given name and returns true if a step has been removed.
+// Steps are identified by name only, similar to DependencyRecorder.RequireDependencyByStepName, so if multiple steps share the same name, only the first occurrence is removed.
+func (p *Pipeline[T]) RemoveStep(name string) bool {
+	p.mustNotBeFrozen()
+	for i, step := range p.steps {
+		if step.Name == name {
+			p.steps = append(p.steps[:i:i], p.steps[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // AddStepFromFunc appends the given function to the Pipeline at the end and returns itself.
 func (p *Pipeline[T]) AddStepFromFunc(name string, fn ActionFunc[T]) *Pipeline[T] {
 	return p.AddStep(NewStep[T](name, fn))
 }
 
-// WithSteps appends the given array of steps to the Pipeline at the end and returns itself.
+// WithSteps sets the given array of steps to the Pipeline and returns itself.
+// Any previously added steps are replaced, use AppendSteps to keep them.
 func (p *Pipeline[T]) WithSteps(steps ...Step[T]) *Pipeline[T] {
+	p.mustNotBeFrozen()
 	p.steps = steps
 	return p
 }
 
+// AppendSteps appends the given steps to the Pipeline at the end and returns itself.
+// Unlike WithSteps, previously added steps are kept.
+func (p *Pipeline[T]) AppendSteps(steps ...Step[T]) *Pipeline[T] {
+	p.mustNotBeFrozen()
+	p.steps = append(p.steps, steps...)
+	return p
+}
+
+// Len returns the number of steps in the Pipeline.
+// Steps of nested pipelines are not counted.
+func (p *Pipeline[T]) Len() int {
+	return len(p.steps)
+}
+
+// StepNames returns the names of the steps in the Pipeline in order.
+func (p *Pipeline[T]) StepNames() []string {
+	names := make([]string, len(p.steps))
+	for i, step := range p.steps {
+		names[i] = step.Name
+	}
+	return names
+}
+
+// Step returns the first step whose Step.Name equals the given name and true, or an empty Step and false if there is none.
+// If multiple steps share the same name, only the first occurrence is returned, similar to RemoveStep.
+// The returned Step is a copy, use RemoveStep and InsertStep to replace it with a reconfigured step.
+func (p *Pipeline[T]) Step(name string) (Step[T], bool) {
+	for _, step := range p.steps {
+		if step.Name == name {
+			return step, true
+		}
+	}
+	return Step[T]{}, false
+}
+
 // WithNestedSteps is similar to AsNestedStep, but it accepts the steps given directly as parameters.
 // When predicate is non-nil then the steps are only executed if it evaluates to `true`.
 func (p *Pipeline[T]) WithNestedSteps(name string, predicate Predicate[T], steps ...Step[T]) Step[T] {
-	return NewStepIf[T](predicate, name, func(ctx T) error {
-		nested := &Pipeline[T]{beforeHooks: p.beforeHooks, steps: steps, options: p.options}
-		return nested.RunWithContext(ctx)
+	step := NewStepIf[T](predicate, name, func(ctx T) error {
+		return p.nested(steps).RunWithContext(ctx)
 	})
+	step.nestedSteps = func() []Step[T] {
+		return steps
+	}
+	return step
 }
 
 // AsNestedStep converts the Pipeline instance into a Step that can be used in other pipelines.
 // The properties are passed to the nested pipeline.
 func (p *Pipeline[T]) AsNestedStep(name string) Step[T] {
-	return NewStep[T](name, func(ctx T) error {
-		nested := &Pipeline[T]{beforeHooks: p.beforeHooks, steps: p.steps, options: p.options}
-		return nested.RunWithContext(ctx)
+	step := NewStep[T](name, func(ctx T) error {
+		return p.nested(p.steps).RunWithContext(ctx)
 	})
+	step.nestedSteps = func() []Step[T] {
+		return p.steps
+	}
+	return step
+}
+
+// nested returns a new Pipeline with the given steps that inherits the properties of p.
+func (p *Pipeline[T]) nested(steps []Step[T]) *Pipeline[T] {
+	return &Pipeline[T]{beforeHooks: p.beforeHooks, afterHooks: p.afterHooks, skippedHooks: p.skippedHooks, steps: steps, options: p.options, events: p.events, middleware: p.middleware, isNested: true}
+}
+
+// Clone returns a new Pipeline with the same steps, hooks, finalizers, middleware and options, e.g. to run variations of a base pipeline concurrently.
+// The slices are copied, so that adding steps or hooks to the clone doesn't affect p and vice versa.
+// The steps themselves are shallow copies, hence the clone shares the actions and other function values with p.
+// If WithStepCounter is enabled, the clone counts the invocations separately.
+func (p *Pipeline[T]) Clone() *Pipeline[T] {
+	clone := &Pipeline[T]{
+		steps:           slices.Clone(p.steps),
+		beforeHooks:     slices.Clone(p.beforeHooks),
+		afterHooks:      slices.Clone(p.afterHooks),
+		skippedHooks:    slices.Clone(p.skippedHooks),
+		finalizers:      slices.Clone(p.finalizers),
+		resultFinalizer: p.resultFinalizer,
+		options:         p.options,
+		events:          p.events,
+		middleware:      slices.Clone(p.middleware),
+		mutableContext:  p.mutableContext,
+	}
+	clone.options.AbortOn = slices.Clone(p.options.AbortOn)
+	if p.counter != nil {
+		clone.counter = &stepCounter{}
+	}
+	return clone
 }
 
 // WithFinalizer returns itself while setting the finalizer for the pipeline.
 // The finalizer is a handler that gets called after the last step is in the pipeline is completed.
 // If a pipeline aborts early or gets canceled then it is also called.
+// Any finalizers previously set are replaced, use WithFinalizers to add multiple finalizers.
 func (p *Pipeline[T]) WithFinalizer(handler ErrorHandler[T]) *Pipeline[T] {
-	p.finalizer = handler
+	p.mustNotBeFrozen()
+	p.finalizers = []ErrorHandler[T]{handler}
+	return p
+}
+
+// WithFinalizers appends the given finalizers and returns itself.
+// The finalizers are called in the order they have been added, see WithFinalizer.
+// The first finalizer receives the pipeline's result, each subsequent finalizer receives the error returned by the previous finalizer.
+// Thus, each finalizer may transform or clear the error, and the error returned by the last finalizer is returned by the pipeline.
+func (p *Pipeline[T]) WithFinalizers(handlers ...ErrorHandler[T]) *Pipeline[T] {
+	p.mustNotBeFrozen()
+	p.finalizers = append(p.finalizers, handlers...)
+	return p
+}
+
+// WithResultFinalizer returns itself while setting a finalizer that receives the Result of the pipeline, e.g. to branch cleanup logic depending on which step failed.
+// Unlike the finalizers of WithFinalizer and WithFinalizers, it can't alter the error returned by the pipeline.
+// It is called after the last step is completed or the pipeline aborted, and before the other finalizers.
+func (p *Pipeline[T]) WithResultFinalizer(finalizer FinalizerFunc[T]) *Pipeline[T] {
+	p.mustNotBeFrozen()
+	p.resultFinalizer = finalizer
 	return p
 }
 
@@ -93,8 +297,9 @@ func (p *Pipeline[T]) When(predicate Predicate[T], name string, action ActionFun
 // Upon cancellation of the context, the pipeline does not terminate a currently running step, instead it skips the remaining steps in the execution order.
 // The context is passed to each Step.Action and each Step may need to listen to the context cancellation event to truly cancel a long-running step.
 // If the pipeline gets canceled, the context's error is returned.
+// If the context has been canceled with a cause (see context.WithCancelCause), the error wraps the cause as well.
 //
-// All non-nil errors, except the error returned from the pipeline's finalizer, are wrapped in Result.
+// All non-nil errors, except the error returned from the pipeline's finalizers, are wrapped in Result.
 // This can be used to retrieve the metadata of the step that returned the error with errors.As:
 //  err := p.RunWithContext(ctx)
 //  var result pipeline.Result
@@ -102,49 +307,260 @@ func (p *Pipeline[T]) When(predicate Predicate[T], name string, action ActionFun
 //    fmt.Println(result.Name())
 //  }
 func (p *Pipeline[T]) RunWithContext(ctx T) error {
-	result := p.doRun(ctx)
-	if p.finalizer != nil {
-		err := p.finalizer(ctx, result)
-		return err
+	return p.run(ctx, &Summary{}, p.doRun)
+}
+
+// run runs the pipeline with the given executor, which runs the steps.
+func (p *Pipeline[T]) run(ctx T, summary *Summary, executor func(ctx T, options Options, summary *Summary) Result) error {
+	start := time.Now()
+	defer func() {
+		summary.Duration = time.Since(start)
+	}()
+	options := p.options.forRun(ctx)
+	if options.ValidateBeforeRun {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+	}
+	if (p.mutableContext || options.AutoMutableContext) && ctx.Value(contextKey{}) == nil {
+		ctx = deriveContext(ctx, MutableContext(ctx))
+	}
+	result := executor(ctx, options, summary)
+	if p.resultFinalizer != nil {
+		p.resultFinalizer(ctx, result)
 	}
+	var err error = result
+	if skipsRemaining(result) && !p.isNested {
+		err = ErrSkipRemaining
+	}
+	for _, finalizer := range p.finalizers {
+		if finalizer != nil {
+			err = finalizer(ctx, err)
+		}
+	}
+	p.emit(options, Event{Kind: PipelineFinished, Err: err, Duration: time.Since(start)})
+	return err
+}
+
+// RunWithTimeout is similar to RunWithContext, except the pipeline is run with a context derived from ctx that is canceled after the given duration.
+// The derived context is always released once the pipeline has finished.
+// If T is not context.Context, it has to implement ContextDeriver, otherwise this method panics.
+func (p *Pipeline[T]) RunWithTimeout(ctx T, d time.Duration) error {
+	derived, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+	return p.RunWithContext(deriveContext(ctx, derived))
+}
+
+// RunAsync is similar to RunWithContext, except it runs the pipeline in a new Go routine and returns immediately.
+// The final error (or nil) is delivered on the returned channel, which is closed afterwards.
+// The channel is buffered, so the Go routine terminates even if the result is never received.
+// The caller is responsible for canceling the pipeline through the given context.
+func (p *Pipeline[T]) RunAsync(ctx T) <-chan error {
+	result := make(chan error, 1)
+	go func() {
+		defer close(result)
+		result <- p.RunWithContext(ctx)
+	}()
 	return result
 }
 
-func (p *Pipeline[T]) doRun(ctx T) Result {
-	for _, step := range p.steps {
+func (p *Pipeline[T]) doRun(ctx T, options Options, summary *Summary) Result {
+	start := time.Now()
+	summary.Total = len(p.steps)
+	result := p.runSteps(ctx, options, summary, start)
+	return p.runDeferred(ctx, options, summary, result, start)
+}
+
+// runSteps runs all steps in order except the ones marked with Defer.
+func (p *Pipeline[T]) runSteps(ctx T, options Options, summary *Summary, start time.Time) Result {
+	errs := &MultiError{}
+	jumps := 0
+	for i := 0; i < len(p.steps); i++ {
+		step := p.steps[i]
+		if step.deferred {
+			continue
+		}
 		select {
 		case <-ctx.Done():
-			result := p.fail(ctx.Err(), step)
-			return result
+			if options.CancellationSkipsQuietly {
+				for _, skipped := range p.steps[i:] {
+					if skipped.deferred {
+						continue
+					}
+					p.skip(skipped, contextError(ctx).Error(), options)
+					summary.Skipped++
+				}
+				return p.aggregate(errs, newResult("", contextError(ctx), time.Since(start)), start)
+			}
+			summary.Failed++
+			result := p.fail(contextError(ctx), step, options, time.Since(start))
+			return p.aggregate(errs, result, start)
 		default:
-			if step.Condition != nil {
-				skipStep := !step.Condition(ctx)
-				if skipStep {
+			if result := p.runStep(ctx, step, options, summary); result != nil {
+				if target, isJump := p.jumpTarget(step, result); isJump {
+					if result = p.jump(step, target, &jumps, options, summary); result == nil {
+						i = target - 1
+						continue
+					}
+				}
+				if options.aborts(result) {
+					return p.aggregate(errs, nil, start)
+				}
+				if options.ContinueOnError && !skipsRemaining(result) {
+					errs.Append(step.Name, result)
 					continue
 				}
+				return p.aggregate(errs, result, start)
 			}
-			for _, hooks := range p.beforeHooks {
-				hooks(step)
-			}
+		}
+	}
+	return p.aggregate(errs, nil, start)
+}
 
-			err := step.Action(ctx)
-			if step.Handler != nil {
-				err = step.Handler(ctx, err)
-			}
-			if err != nil {
-				return p.fail(err, step)
+// jump returns a failed Result if the jump to the given target index isn't possible, or nil otherwise.
+func (p *Pipeline[T]) jump(step Step[T], target int, jumps *int, options Options, summary *Summary) Result {
+	var err error
+	switch {
+	case target < 0:
+		err = fmt.Errorf("unknown jump target %q", step.jumpTarget)
+	case *jumps >= options.maxJumps():
+		err = fmt.Errorf("exceeded maximum of %d jumps", options.maxJumps())
+	default:
+		*jumps++
+		return nil
+	}
+	summary.Failed++
+	return p.fail(err, step, options, 0)
+}
+
+// runDeferred runs the steps marked with Defer in reverse order, regardless of the result of the other steps.
+// The errors of failed deferred steps are collected after the given result.
+func (p *Pipeline[T]) runDeferred(ctx T, options Options, summary *Summary, result Result, start time.Time) Result {
+	errs := &MultiError{}
+	for i := len(p.steps) - 1; i >= 0; i-- {
+		if step := p.steps[i]; step.deferred {
+			if deferredResult := p.runStep(ctx, step, options, summary); deferredResult != nil && !options.aborts(deferredResult) {
+				errs.Append(step.Name, deferredResult)
 			}
 		}
 	}
+	switch {
+	case len(errs.Results) == 0:
+		return result
+	case result == nil && len(errs.Results) == 1:
+		return errs.Results[0]
+	case result != nil:
+		errs.Results = append([]Result{result}, errs.Results...)
+	}
+	return newResult("", errs, time.Since(start))
+}
+
+// invocations is the source of Step.invocation.
+var invocations atomic.Uint64
+
+// runStep runs the given step unless its condition evaluates to false, and returns a Result if the step failed.
+func (p *Pipeline[T]) runStep(ctx T, step Step[T], options Options, summary *Summary) Result {
+	if step.Condition != nil && !step.Condition(ctx) {
+		p.skip(step, step.skipReason(), options)
+		summary.Skipped++
+		return nil
+	}
+	summary.Ran++
+	step.invocation = invocations.Add(1)
+	if p.counter != nil {
+		p.counter.increment(step.Name)
+	}
+	for _, hooks := range p.beforeHooks {
+		hooks(step)
+	}
+	for _, hooks := range step.BeforeHooks {
+		hooks(step)
+	}
+	p.emit(options, Event{Kind: StepStarted, StepName: step.Name})
+
+	actionStart := time.Now()
+	err := runAction(ctx, step, options, p.applyMiddleware(step))
+	duration := time.Since(actionStart)
+	if step.Handler != nil {
+		err = step.Handler(ctx, err)
+	}
+	if err == nil && step.SuccessHandler != nil {
+		step.SuccessHandler(ctx)
+	}
+	for _, hooks := range p.afterHooks {
+		hooks(step, err)
+	}
+	p.emit(options, Event{Kind: StepFinished, StepName: step.Name, Err: err, Duration: duration})
+	if err != nil {
+		if !options.aborts(err) && step.jumpTarget == "" {
+			summary.Failed++
+		}
+		return p.fail(err, step, options, duration)
+	}
 	return nil
 }
 
-func (p *Pipeline[T]) fail(err error, step Step[T]) Result {
+// aggregate returns the given result if no errors have been collected due to Options.ContinueOnError.
+// Otherwise, the result is appended to the collected errors, which are returned as a Result with an empty name.
+func (p *Pipeline[T]) aggregate(errs *MultiError, result Result, start time.Time) Result {
+	if len(errs.Results) == 0 {
+		return result
+	}
+	if result != nil {
+		errs.Append(result.Name(), result)
+	}
+	return newResult("", errs, time.Since(start))
+}
+
+// runAction invokes the given action of the step with the step context, and recovers from panics if enabled.
+func runAction[T context.Context](ctx T, step Step[T], options Options, action ActionFunc[T]) (err error) {
+	stepCtx, cancel := stepContext(ctx, step, options)
+	defer cancel()
+	if options.RecoverPanics {
+		defer func() {
+			if r := recover(); r != nil {
+				err = newPanicError(r)
+			}
+		}()
+	}
+	return action(stepCtx)
+}
+
+// stepContext returns the context for the step's action, which is bounded by Step.Timeout or Options.DefaultStepTimeout.
+// With Options.PerStepContext, it is isolated from the contexts of the other steps.
+func stepContext[T context.Context](ctx T, step Step[T], options Options) (T, context.CancelFunc) {
+	if options.PerStepContext {
+		ctx = deriveContext(ctx, isolatedContext(ctx))
+	}
+	timeout := step.Timeout
+	if timeout == 0 {
+		timeout = options.DefaultStepTimeout
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	derived, cancel := context.WithTimeout(ctx, timeout)
+	return deriveContext(ctx, derived), cancel
+}
+
+func (p *Pipeline[T]) skip(step Step[T], reason string, options Options) {
+	for _, hooks := range p.skippedHooks {
+		hooks(step, reason)
+	}
+	p.emit(options, Event{Kind: StepSkipped, StepName: step.Name})
+}
+
+func (p *Pipeline[T]) fail(err error, step Step[T], options Options, duration time.Duration) Result {
 	var resultErr error
-	if p.options.DisableErrorWrapping {
+	_, isPanic := err.(*PanicError)
+	_, isItem := err.(*ItemError)
+	switch {
+	case options.DisableErrorWrapping:
 		resultErr = err
-	} else {
+	case isPanic, isItem:
+		resultErr = fmt.Errorf("step '%s' %w", step.Name, err)
+	default:
 		resultErr = fmt.Errorf("step '%s' failed: %w", step.Name, err)
 	}
-	return newResult(step.Name, resultErr)
+	return resultImpl{name: step.Name, err: resultErr, duration: duration, skipRemaining: isSkipRemaining(err)}
 }
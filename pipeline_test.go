@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
 )
 
 type hook struct {
@@ -174,6 +175,25 @@ func TestPipeline_RunWithContext_CancelLongRunningStep(t *testing.T) {
 	assert.EqualError(t, err, "step 'long running' failed: context canceled")
 }
 
+func TestPipeline_RunWithContext_CancelCause(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	p := NewPipeline[context.Context]()
+	p.WithSteps(
+		p.NewStep("abort", func(_ context.Context) error {
+			cancel(errors.New("user aborted"))
+			return nil
+		}),
+		p.NewStep("never", failingAction),
+	)
+	err := p.RunWithContext(ctx)
+	assert.EqualError(t, err, "step 'never' failed: context canceled: user aborted")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	step := NewFanOutStep[context.Context]("fanout", SupplierFromSlice[context.Context](nil), nil)
+	err = step.Action(ctx)
+	assert.EqualError(t, err, "context canceled: user aborted")
+}
+
 func TestPipeline_RunWithContext_ErrorAs(t *testing.T) {
 	p := NewPipeline[context.Context]()
 	p.WithSteps(p.NewStep("error-as", func(ctx context.Context) error {
@@ -187,6 +207,452 @@ func TestPipeline_RunWithContext_ErrorAs(t *testing.T) {
 	}
 }
 
+func TestPipeline_RunWithContext_ResultDuration(t *testing.T) {
+	t.Run("GivenFailingStep_ThenReportActionDuration", func(t *testing.T) {
+		p := NewPipeline[context.Context]().AddStepFromFunc("slow", func(_ context.Context) error {
+			time.Sleep(20 * time.Millisecond)
+			return errors.New("failed")
+		})
+		err := p.RunWithContext(context.Background())
+		var result Result
+		require.True(t, errors.As(err, &result))
+		assert.GreaterOrEqual(t, result.Duration(), 20*time.Millisecond)
+	})
+	t.Run("GivenCanceledContext_ThenReportElapsedTime", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		p := NewPipeline[context.Context]().WithSteps(
+			NewStep("slow", func(_ context.Context) error {
+				time.Sleep(20 * time.Millisecond) // ignores cancellation
+				return nil
+			}),
+			NewStep("canceled", failingAction),
+		)
+		err := p.RunWithContext(ctx)
+		var result Result
+		require.True(t, errors.As(err, &result))
+		assert.Equal(t, "canceled", result.Name())
+		assert.GreaterOrEqual(t, result.Duration(), 20*time.Millisecond)
+	})
+}
+
+func TestPipeline_RunWithContext_Condition(t *testing.T) {
+	p := NewPipeline[context.Context]().
+		AddStep(NewStep("skipped", failingAction).When(Bool[context.Context](false))).
+		AddStep(NewStep("skipped if", failingAction).When(Not(Bool[context.Context](true))))
+	err := p.RunWithContext(context.Background())
+	assert.NoError(t, err, "action should not run")
+}
+
+func TestPipeline_RunWithTimeout(t *testing.T) {
+	t.Run("GivenSlowStep_ThenCancelAfterTimeout", func(t *testing.T) {
+		p := NewPipeline[context.Context]().AddStep(NewStep("slow", sleepUntilDone(time.Second)))
+		start := time.Now()
+		err := p.RunWithTimeout(context.Background(), 10*time.Millisecond)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+	t.Run("GivenCustomContext_ThenDeriveContext", func(t *testing.T) {
+		p := NewPipeline[*derivableContext]().AddStepFromFunc("check", func(ctx *derivableContext) error {
+			_, hasDeadline := ctx.Deadline()
+			assert.True(t, hasDeadline)
+			assert.Equal(t, "value", ctx.field)
+			return nil
+		})
+		err := p.RunWithTimeout(&derivableContext{Context: context.Background(), field: "value"}, time.Second)
+		assert.NoError(t, err)
+	})
+	t.Run("GivenContextWithoutDeriver_ThenPanic", func(t *testing.T) {
+		p := NewPipeline[*testContext]()
+		assert.PanicsWithError(t, "cannot derive context: *pipeline.testContext does not implement ContextDeriver", func() {
+			_ = p.RunWithTimeout(&testContext{Context: context.Background()}, time.Second)
+		})
+	})
+}
+
+func TestPipeline_WithFinalizers(t *testing.T) {
+	var calls []string
+	p := NewPipeline[context.Context]().
+		AddStepFromFunc("failing", func(_ context.Context) error {
+			return errors.New("failed")
+		}).
+		AddStep(NewStep("not run", failingAction)).
+		WithFinalizer(func(_ context.Context, err error) error {
+			calls = append(calls, "replaced")
+			return err
+		})
+	p.WithFinalizer(func(_ context.Context, err error) error {
+		calls = append(calls, "first: "+err.Error())
+		return fmt.Errorf("transformed: %w", err)
+	}).WithFinalizers(
+		func(_ context.Context, err error) error {
+			calls = append(calls, "second: "+err.Error())
+			return nil
+		},
+		func(_ context.Context, err error) error {
+			assert.NoError(t, err, "cleared by previous finalizer")
+			calls = append(calls, "third")
+			return errors.New("last")
+		},
+	)
+	err := p.RunWithContext(context.Background())
+	assert.EqualError(t, err, "last")
+	assert.Equal(t, []string{
+		"first: step 'failing' failed: failed",
+		"second: transformed: step 'failing' failed: failed",
+		"third",
+	}, calls)
+}
+
+func TestPipeline_WithResultFinalizer(t *testing.T) {
+	tests := map[string]struct {
+		givenSteps   []Step[context.Context]
+		expectedName string
+		expectNil    bool
+	}{
+		"GivenSuccessfulPipeline_ThenResultIsNil": {
+			givenSteps: []Step[context.Context]{newTestStep("success")},
+			expectNil:  true,
+		},
+		"GivenFailingStep_ThenResultHasStepName": {
+			givenSteps:   []Step[context.Context]{newTestStep("success"), NewStep("failing", failingAction)},
+			expectedName: "failing",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			called := false
+			p := NewPipeline[context.Context]().WithSteps(tc.givenSteps...)
+			p.WithResultFinalizer(func(_ context.Context, result Result) {
+				called = true
+				if tc.expectNil {
+					assert.Nil(t, result)
+					return
+				}
+				require.NotNil(t, result)
+				assert.Equal(t, tc.expectedName, result.Name())
+			}).WithFinalizer(func(_ context.Context, err error) error {
+				assert.True(t, called, "result finalizer called first")
+				return err
+			})
+			_ = p.RunWithContext(context.Background())
+			assert.True(t, called)
+		})
+	}
+}
+
+func TestPipeline_RunAsync(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	tests := map[string]struct {
+		givenError    error
+		expectedError string
+	}{
+		"GivenSuccessfulPipeline_ThenDeliverNil": {},
+		"GivenFailingPipeline_ThenDeliverError": {
+			givenError:    errors.New("failed"),
+			expectedError: "step 'async' failed: failed",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := NewPipeline[context.Context]().AddStepFromFunc("async", func(_ context.Context) error {
+				time.Sleep(10 * time.Millisecond)
+				return tc.givenError
+			})
+			result := p.RunAsync(context.Background())
+			select {
+			case err := <-result:
+				if tc.expectedError != "" {
+					assert.EqualError(t, err, tc.expectedError)
+				} else {
+					assert.NoError(t, err)
+				}
+			case <-time.After(time.Second):
+				require.Fail(t, "timeout waiting for result")
+			}
+			_, open := <-result
+			assert.False(t, open, "channel closed")
+		})
+	}
+}
+
+func TestPipeline_WithAfterHooks(t *testing.T) {
+	var events []string
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := NewPipeline[context.Context]().
+		WithBeforeHooks(func(step Step[context.Context]) {
+			events = append(events, "before "+step.Name)
+		}).
+		WithAfterHooks(func(step Step[context.Context], err error) {
+			events = append(events, fmt.Sprintf("after %s: %v", step.Name, err))
+		})
+	p.WithSteps(
+		p.NewStep("handled", func(_ context.Context) error {
+			return errors.New("original")
+		}).WithErrorHandler(func(_ context.Context, err error) error {
+			return nil
+		}),
+		p.When(Bool[context.Context](false), "skipped", failingAction),
+		p.NewStep("failing", func(_ context.Context) error {
+			return errors.New("failed")
+		}).WithErrorHandler(func(_ context.Context, err error) error {
+			cancel()
+			return fmt.Errorf("handled: %w", err)
+		}),
+	)
+	err := p.RunWithContext(ctx)
+	assert.EqualError(t, err, "step 'failing' failed: handled: failed")
+	assert.Equal(t, []string{
+		"before handled",
+		"after handled: <nil>",
+		"before failing",
+		"after failing: handled: failed",
+	}, events)
+
+	t.Run("GivenCanceledContext_ThenDontCallHooks", func(t *testing.T) {
+		events = nil
+		err := p.RunWithContext(ctx)
+		assert.EqualError(t, err, "step 'handled' failed: context canceled")
+		assert.Empty(t, events)
+	})
+}
+
+func TestPipeline_WithSkippedHooks(t *testing.T) {
+	var skipped []string
+	p := NewPipeline[context.Context]().WithSkippedHooks(func(step Step[context.Context], reason string) {
+		skipped = append(skipped, step.Name+": "+reason)
+	})
+	p.WithSteps(
+		p.When(Bool[context.Context](false), "skipped", func(_ context.Context) error {
+			return nil
+		}),
+		p.When(Bool[context.Context](true), "run", func(_ context.Context) error {
+			return nil
+		}),
+		p.WithNestedSteps("nested", nil,
+			p.When(Bool[context.Context](false), "nested skipped", func(_ context.Context) error {
+				return nil
+			}),
+		),
+	)
+	err := p.RunWithContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"skipped: condition evaluated to false", "nested skipped: condition evaluated to false"}, skipped)
+}
+
+func TestNewMutablePipeline(t *testing.T) {
+	t.Run("GivenStandardContext_ThenStoreInContext", func(t *testing.T) {
+		var value any
+		p := NewMutablePipeline[context.Context]()
+		p.WithSteps(
+			p.NewStep("store", func(ctx context.Context) error {
+				StoreInContext(ctx, "key", "value")
+				return nil
+			}),
+			p.NewStep("load", func(ctx context.Context) error {
+				value = MustLoadFromContext(ctx, "key")
+				return nil
+			}),
+		)
+		err := p.RunWithContext(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "value", value)
+	})
+	t.Run("GivenMutableContext_ThenReuseContext", func(t *testing.T) {
+		ctx := MutableContext(context.Background())
+		p := NewMutablePipeline[context.Context]()
+		p.AddStepFromFunc("store", func(ctx context.Context) error {
+			StoreInContext(ctx, "key", "value")
+			return nil
+		})
+		err := p.RunWithContext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "value", MustLoadFromContext(ctx, "key"))
+	})
+	t.Run("GivenContextDeriver_ThenStoreInContext", func(t *testing.T) {
+		p := NewMutablePipeline[*derivableContext]()
+		p.AddStepFromFunc("store", func(ctx *derivableContext) error {
+			StoreInContext(ctx, "key", ctx.field)
+			return nil
+		}).WithFinalizer(func(ctx *derivableContext, err error) error {
+			assert.Equal(t, "value", MustLoadFromContext(ctx, "key"))
+			return err
+		})
+		err := p.RunWithContext(&derivableContext{Context: context.Background(), field: "value"})
+		require.NoError(t, err)
+	})
+	t.Run("GivenCustomContextWithoutDeriver_ThenPanic", func(t *testing.T) {
+		p := NewMutablePipeline[*testContext]()
+		assert.PanicsWithError(t, "cannot derive context: *pipeline.testContext does not implement ContextDeriver", func() {
+			_ = p.RunWithContext(&testContext{Context: context.Background()})
+		})
+	})
+}
+
+func TestPipeline_InsertStep(t *testing.T) {
+	tests := map[string]struct {
+		givenIndex    int
+		expectedOrder []string
+		expectedPanic string
+	}{
+		"GivenIndexZero_ThenInsertAtHead": {
+			givenIndex:    0,
+			expectedOrder: []string{"inserted", "first", "second"},
+		},
+		"GivenIndexInBetween_ThenInsertInMiddle": {
+			givenIndex:    1,
+			expectedOrder: []string{"first", "inserted", "second"},
+		},
+		"GivenIndexEqualToLength_ThenInsertAtTail": {
+			givenIndex:    2,
+			expectedOrder: []string{"first", "second", "inserted"},
+		},
+		"GivenNegativeIndex_ThenPanic": {
+			givenIndex:    -1,
+			expectedPanic: "step index -1 out of range [0, 2]",
+		},
+		"GivenIndexGreaterThanLength_ThenPanic": {
+			givenIndex:    3,
+			expectedPanic: "step index 3 out of range [0, 2]",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var order []string
+			record := func(name string) Step[context.Context] {
+				return NewStep(name, func(_ context.Context) error {
+					order = append(order, name)
+					return nil
+				})
+			}
+			p := NewPipeline[context.Context]().WithSteps(record("first"), record("second"))
+			if tc.expectedPanic != "" {
+				assert.PanicsWithError(t, tc.expectedPanic, func() {
+					p.InsertStep(tc.givenIndex, record("inserted"))
+				})
+				return
+			}
+			err := p.InsertStep(tc.givenIndex, record("inserted")).RunWithContext(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOrder, order)
+		})
+	}
+}
+
+func TestPipeline_PrependStep(t *testing.T) {
+	var order []string
+	record := func(name string) Step[context.Context] {
+		return NewStep(name, func(_ context.Context) error {
+			order = append(order, name)
+			return nil
+		})
+	}
+	p := NewPipeline[context.Context]().
+		AddStep(record("first")).
+		AddStep(record("second")).
+		PrependStep(record("setup"))
+	err := p.RunWithContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"setup", "first", "second"}, order)
+}
+
+func TestPipeline_Clone(t *testing.T) {
+	var finalized []string
+	p := NewPipeline[context.Context]().
+		AddStep(NewStep("first", failingAction)).
+		AddStep(NewStep("second", failingAction)).
+		AddStep(NewStep("third", failingAction)).
+		WithOptions(Options{ContinueOnError: true}).
+		WithFinalizer(func(_ context.Context, err error) error {
+			finalized = append(finalized, "base")
+			return err
+		})
+
+	clone := p.Clone().AddStep(NewStep("clone", failingAction))
+	p.AddStep(NewStep("original", failingAction))
+	assert.Equal(t, []string{"first", "second", "third", "original"}, p.StepNames())
+	assert.Equal(t, []string{"first", "second", "third", "clone"}, clone.StepNames())
+
+	clone.WithFinalizer(func(_ context.Context, err error) error {
+		finalized = append(finalized, "clone")
+		return err
+	})
+	err := p.RunWithContext(context.Background())
+	assert.Error(t, err)
+	err = clone.RunWithContext(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, []string{"base", "clone"}, finalized)
+	var multiErr *MultiError
+	require.ErrorAs(t, err, &multiErr)
+	assert.Len(t, multiErr.Results, 4, "options are retained")
+}
+
+func TestPipeline_RemoveStep(t *testing.T) {
+	var order []string
+	record := func(name string) Step[context.Context] {
+		return NewStep(name, func(_ context.Context) error {
+			order = append(order, name)
+			return nil
+		})
+	}
+	steps := []Step[context.Context]{record("first"), record("second"), record("third"), record("second")}
+	p := NewPipeline[context.Context]().WithSteps(steps...)
+
+	assert.True(t, p.RemoveStep("second"))
+	assert.False(t, p.RemoveStep("unknown"))
+	err := p.RunWithContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "third", "second"}, order, "only first occurrence removed")
+	assert.Equal(t, "second", steps[1].Name, "given slice unchanged")
+}
+
+func TestPipeline_AppendSteps(t *testing.T) {
+	var order []string
+	record := func(name string) Step[context.Context] {
+		return NewStep(name, func(_ context.Context) error {
+			order = append(order, name)
+			return nil
+		})
+	}
+	p := NewPipeline[context.Context]().
+		AddStep(record("first")).
+		AppendSteps(record("second"), record("third")).
+		AppendSteps()
+	err := p.RunWithContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "third"}, order)
+}
+
+func TestPipeline_StepNames(t *testing.T) {
+	p := NewPipeline[context.Context]()
+	assert.Equal(t, 0, p.Len())
+	assert.Empty(t, p.StepNames())
+
+	p.WithSteps(newTestStep("first"), newTestStep("second"), newTestStep("third"))
+	assert.Equal(t, 3, p.Len())
+	assert.Equal(t, []string{"first", "second", "third"}, p.StepNames())
+}
+
+func TestPipeline_Step(t *testing.T) {
+	p := NewPipeline[context.Context]().WithSteps(
+		newTestStep("first"),
+		newTestStep("duplicate").WithActionID("first duplicate"),
+		newTestStep("duplicate").WithActionID("second duplicate"),
+	)
+	t.Run("GivenExistingName_ThenReturnFirstMatch", func(t *testing.T) {
+		step, found := p.Step("duplicate")
+		assert.True(t, found)
+		assert.Equal(t, "first duplicate", step.ActionID)
+	})
+	t.Run("GivenUnknownName_ThenReturnFalse", func(t *testing.T) {
+		step, found := p.Step("unknown")
+		assert.False(t, found)
+		assert.Empty(t, step.Name)
+		assert.Nil(t, step.Action)
+	})
+}
+
 func ExamplePipeline_RunWithContext() {
 	// prepare pipeline
 	type exampleContext struct {
@@ -231,3 +697,12 @@ type testContext struct {
 	context.Context
 	count int64
 }
+
+type derivableContext struct {
+	context.Context
+	field string
+}
+
+func (c *derivableContext) DeriveContext(ctx context.Context) *derivableContext {
+	return &derivableContext{Context: ctx, field: c.field}
+}
@@ -37,3 +37,21 @@ func (d NoResolver[T]) RequireDependencyByFuncName(_ ...pipeline.ActionFunc[T])
 func (d NoResolver[T]) MustRequireDependencyByFuncName(_ ...pipeline.ActionFunc[T]) {
 	// noop
 }
+
+func (d NoResolver[T]) RequireDependencyByActionID(_ ...string) error {
+	// noop
+	return nil
+}
+
+func (d NoResolver[T]) MustRequireDependencyByActionID(_ ...string) {
+	// noop
+}
+
+func (d NoResolver[T]) RequireDependencyBefore(_, _ string) error {
+	// noop
+	return nil
+}
+
+func (d NoResolver[T]) MustRequireDependencyBefore(_, _ string) {
+	// noop
+}
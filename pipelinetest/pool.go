@@ -0,0 +1,18 @@
+package pipelinetest
+
+import (
+	"context"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+)
+
+// NewSyncWorkerPoolStep returns a pipeline.Step with the same API as pipeline.NewWorkerPoolStep, but it runs all supplied pipelines one after another in supply order.
+// The size is only validated, the step is a pipeline.NewWorkerPoolStep with a single worker.
+// Therefore, it behaves the same as the real pool regarding results, cancellation and recovered panics, while the step waits for all its Go routines before it returns.
+// This can be used in unit tests to avoid timing nondeterminism and leaking Go routines.
+func NewSyncWorkerPoolStep[T context.Context](name string, size int, pipelineSupplier pipeline.Supplier[T], handler pipeline.ParallelResultHandler[T]) pipeline.Step[T] {
+	if size < 1 {
+		panic("pool size cannot be lower than 1")
+	}
+	return pipeline.NewWorkerPoolStep[T](name, 1, pipelineSupplier, handler)
+}
@@ -0,0 +1,87 @@
+package pipelinetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestNewSyncWorkerPoolStep(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	var order []int
+	pipes := make([]*pipeline.Pipeline[context.Context], 10)
+	for i := range pipes {
+		n := i
+		pipes[i] = pipeline.NewPipeline[context.Context]().AddStepFromFunc(fmt.Sprintf("job %d", n), func(_ context.Context) error {
+			order = append(order, n)
+			return fmt.Errorf("job %d", n)
+		}).WithOptions(pipeline.Options{DisableErrorWrapping: true})
+	}
+	step := NewSyncWorkerPoolStep("pool", 4, pipeline.SupplierFromSlice(pipes), func(_ context.Context, results map[uint64]error) error {
+		require.Len(t, results, 10)
+		for i := uint64(0); i < 10; i++ {
+			assert.EqualError(t, results[i], fmt.Sprintf("job %d", i))
+		}
+		return nil
+	})
+	err := step.Action(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, order, "execution order")
+}
+
+func TestNewSyncWorkerPoolStep_Cancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	step := NewSyncWorkerPoolStep("pool", 1, pipeline.SupplierFromSlice([]*pipeline.Pipeline[context.Context]{}), func(_ context.Context, _ map[uint64]error) error {
+		return fmt.Errorf("some error")
+	})
+	err := step.Action(ctx)
+	assert.EqualError(t, err, "context canceled, collection error: some error")
+	var result pipeline.Result
+	require.ErrorAs(t, err, &result)
+	assert.Equal(t, "pool", result.Name())
+	assert.True(t, result.IsCanceled())
+}
+
+func TestNewSyncWorkerPoolStep_CancelCause(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	cause := errors.New("shutdown")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(cause)
+	step := NewSyncWorkerPoolStep("pool", 1, pipeline.SupplierFromSlice([]*pipeline.Pipeline[context.Context]{}), nil)
+	err := step.Action(ctx)
+	assert.EqualError(t, err, "context canceled: shutdown")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, cause)
+	var result pipeline.Result
+	require.ErrorAs(t, err, &result)
+	assert.Equal(t, "pool", result.Name())
+}
+
+func TestNewSyncWorkerPoolStep_InvalidSize(t *testing.T) {
+	assert.Panics(t, func() {
+		NewSyncWorkerPoolStep[context.Context]("pool", 0, nil, nil)
+	})
+}
+
+func TestNewSyncWorkerPoolStep_Panic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	step := NewSyncWorkerPoolStep("pool", 2, pipeline.SupplierFromSlice([]*pipeline.Pipeline[context.Context]{
+		pipeline.NewPipeline[context.Context]().AddStepFromFunc("panic", func(_ context.Context) error {
+			panic("boom")
+		}),
+	}), func(_ context.Context, results map[uint64]error) error {
+		require.Len(t, results, 1)
+		assert.ErrorIs(t, results[0], pipeline.ErrPanic)
+		return nil
+	})
+	err := step.Action(context.Background())
+	assert.NoError(t, err)
+}
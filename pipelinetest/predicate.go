@@ -0,0 +1,67 @@
+package pipelinetest
+
+import (
+	"context"
+	"sync/atomic"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+)
+
+// TestingT is the subset of testing.TB that is needed by the assertions in this package.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// PredicateCounter creates instrumented predicates that count how many times they have been evaluated.
+// This can be used for testing whether predicate combinators short-circuit properly.
+type PredicateCounter[T context.Context] struct {
+	evaluations int64
+}
+
+// NewPredicateCounter returns a new PredicateCounter instance.
+func NewPredicateCounter[T context.Context]() *PredicateCounter[T] {
+	return &PredicateCounter[T]{}
+}
+
+// Instrument returns a pipeline.Predicate that evaluates the given predicate and increments the evaluation count.
+func (c *PredicateCounter[T]) Instrument(predicate pipeline.Predicate[T]) pipeline.Predicate[T] {
+	return func(ctx T) bool {
+		atomic.AddInt64(&c.evaluations, 1)
+		return predicate(ctx)
+	}
+}
+
+// True returns an instrumented pipeline.Predicate that always evaluates to true.
+func (c *PredicateCounter[T]) True() pipeline.Predicate[T] {
+	return c.Instrument(pipeline.Bool[T](true))
+}
+
+// False returns an instrumented pipeline.Predicate that always evaluates to false.
+func (c *PredicateCounter[T]) False() pipeline.Predicate[T] {
+	return c.Instrument(pipeline.Bool[T](false))
+}
+
+// Evaluations returns the number of times any of the instrumented predicates have been evaluated since the last Reset.
+func (c *PredicateCounter[T]) Evaluations() int {
+	return int(atomic.LoadInt64(&c.evaluations))
+}
+
+// Reset sets the evaluation count to zero.
+func (c *PredicateCounter[T]) Reset() {
+	atomic.StoreInt64(&c.evaluations, 0)
+}
+
+// AssertShortCircuit evaluates the given predicate once and asserts that the instrumented predicates of counter have been evaluated exactly expectedEvaluations times.
+// The counter is reset before evaluating the predicate.
+// It returns true if the assertion succeeded.
+func AssertShortCircuit[T context.Context](t TestingT, counter *PredicateCounter[T], predicate pipeline.Predicate[T], ctx T, expectedEvaluations int) bool {
+	t.Helper()
+	counter.Reset()
+	_ = predicate(ctx)
+	if actual := counter.Evaluations(); actual != expectedEvaluations {
+		t.Errorf("expected %d predicate evaluations, but got %d", expectedEvaluations, actual)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package pipelinetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssertShortCircuit(t *testing.T) {
+	c := NewPredicateCounter[context.Context]()
+	tests := map[string]struct {
+		givenPredicate      pipeline.Predicate[context.Context]
+		expectedEvaluations int
+	}{
+		"GivenAnd_WhenFirstFalse_ThenEvaluateOnce": {
+			givenPredicate:      pipeline.And(c.False(), c.True()),
+			expectedEvaluations: 1,
+		},
+		"GivenAnd_WhenFirstTrue_ThenEvaluateBoth": {
+			givenPredicate:      pipeline.And(c.True(), c.False()),
+			expectedEvaluations: 2,
+		},
+		"GivenOr_WhenFirstTrue_ThenEvaluateOnce": {
+			givenPredicate:      pipeline.Or(c.True(), c.False()),
+			expectedEvaluations: 1,
+		},
+		"GivenOr_WhenFirstFalse_ThenEvaluateBoth": {
+			givenPredicate:      pipeline.Or(c.False(), c.True()),
+			expectedEvaluations: 2,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			AssertShortCircuit(t, c, tc.givenPredicate, context.Background(), tc.expectedEvaluations)
+		})
+	}
+}
+
+func TestAssertShortCircuit_Fails(t *testing.T) {
+	mock := &mockT{}
+	c := NewPredicateCounter[context.Context]()
+	result := AssertShortCircuit(mock, c, pipeline.And(c.True(), c.True()), context.Background(), 1)
+	assert.False(t, result)
+	assert.Equal(t, "expected 1 predicate evaluations, but got 2", mock.message)
+}
+
+type mockT struct {
+	message string
+}
+
+func (m *mockT) Helper() {}
+
+func (m *mockT) Errorf(format string, args ...any) {
+	m.message = fmt.Sprintf(format, args...)
+}
@@ -2,8 +2,10 @@ package pipeline
 
 import (
 	"context"
+	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 /*
@@ -14,35 +16,113 @@ The step waits until all pipelines are finished.
  * The pipelines are executed in a pool of a number of Go routines indicated by size.
  * If size is 1, the pipelines are effectively run in sequence.
  * If size is 0 or less, the function panics.
+ * If a child pipeline panics, the panic is recovered and the child's result is an error that unwraps to ErrPanic.
 */
 func NewWorkerPoolStep[T context.Context](name string, size int, pipelineSupplier Supplier[T], handler ParallelResultHandler[T]) Step[T] {
+	return NewWorkerPoolStepWithOptions[T](name, size, pipelineSupplier, handler, ParallelOptions{})
+}
+
+// DefaultPoolSize returns the number of Go routines that can run simultaneously, as reported by runtime.GOMAXPROCS.
+// The value is read each time DefaultPoolSize is called.
+func DefaultPoolSize() int {
+	return runtime.GOMAXPROCS(0)
+}
+
+// NewWorkerPoolStepAuto is NewWorkerPoolStep with DefaultPoolSize as pool size.
+// Note that the pool size is determined when NewWorkerPoolStepAuto is called, not when the step runs.
+func NewWorkerPoolStepAuto[T context.Context](name string, pipelineSupplier Supplier[T], handler ParallelResultHandler[T]) Step[T] {
+	return NewWorkerPoolStep[T](name, DefaultPoolSize(), pipelineSupplier, handler)
+}
+
+// NewWorkerPoolStepWithOptions is NewWorkerPoolStep, but the step's behaviour can be altered with ParallelOptions.
+func NewWorkerPoolStepWithOptions[T context.Context](name string, size int, pipelineSupplier Supplier[T], handler ParallelResultHandler[T], options ParallelOptions) Step[T] {
+	return newWorkerPoolStep[T](name, size, size, pipelineSupplier, collectErrors(handler), options)
+}
+
+// NewWorkerPoolStepWithBuffer is NewWorkerPoolStep, but the number of pipelines that the Supplier can put into the channel before they are picked up by a worker is set by buffer.
+// By default, the buffer equals the pool size.
+// A larger buffer decouples a bursty Supplier from the workers.
+// If buffer is 0 or less, the function panics.
+func NewWorkerPoolStepWithBuffer[T context.Context](name string, size, buffer int, pipelineSupplier Supplier[T], handler ParallelResultHandler[T]) Step[T] {
+	return newWorkerPoolStep[T](name, size, buffer, pipelineSupplier, collectErrors(handler), ParallelOptions{})
+}
+
+// NewWorkerPoolStepWithDurations is similar to NewWorkerPoolStep, but the given ParallelDurationHandler also receives the duration of each child pipeline.
+// The duration only includes the time the pipeline took to run, not the time it waited for a free worker.
+func NewWorkerPoolStepWithDurations[T context.Context](name string, size int, pipelineSupplier Supplier[T], handler ParallelDurationHandler[T]) Step[T] {
+	return newWorkerPoolStep[T](name, size, size, pipelineSupplier, collectDurations(handler), ParallelOptions{})
+}
+
+func newWorkerPoolStep[T context.Context](name string, size, buffer int, pipelineSupplier Supplier[T], collect resultCollector[T], options ParallelOptions) Step[T] {
 	if size < 1 {
 		panic("pool size cannot be lower than 1")
 	}
+	if buffer < 1 {
+		panic("buffer size cannot be lower than 1")
+	}
 	step := Step[T]{Name: name}
 	step.Action = func(ctx T) error {
-		pipelineChan := make(chan *Pipeline[T], size)
+		// the dispatcher holds one pipeline, so that in total there are as many pending pipelines as the buffer size.
+		pipelineChan := make(chan *Pipeline[T], buffer-1)
+		jobChan := make(chan poolJob[T])
 		m := sync.Map{}
 		var wg sync.WaitGroup
 		count := uint64(0)
+		runner := newChildRunner(ctx, options)
+		defer runner.close()
+
+		abandoned := make(chan struct{})
 
-		go pipelineSupplier(ctx, pipelineChan)
+		go pipelineSupplier(runner.ctx, pipelineChan)
+		go dispatchPoolJobs(pipelineChan, jobChan, &count, abandoned)
 		for i := 0; i < size; i++ {
 			wg.Add(1)
-			go poolWork(ctx, pipelineChan, &wg, &count, &m)
+			go poolWork(runner, jobChan, &wg, &m, abandoned)
 		}
 
-		wg.Wait()
-		res := collectResults(ctx, handler, &m)
+		waitForChildren(runner.ctx, &wg, options, &count, &m, func() {
+			close(abandoned)
+		})
+		res := collect(ctx, &m)
 		return setResultErrorFromContext(ctx, name, res)
 	}
 	return step
 }
 
-func poolWork[T context.Context](ctx T, pipelineChan chan *Pipeline[T], wg *sync.WaitGroup, i *uint64, m *sync.Map) {
-	defer wg.Done()
+// poolJob is a Pipeline with the zero-based index in which it has been supplied.
+type poolJob[T context.Context] struct {
+	index    uint64
+	pipeline *Pipeline[T]
+}
+
+// dispatchPoolJobs assigns the index to each supplied Pipeline in supply order before handing it to a worker.
+// Once abandoned is closed, it stops handing out pipelines and discards the remaining supplied pipelines, so that the Supplier doesn't block.
+func dispatchPoolJobs[T context.Context](pipelineChan chan *Pipeline[T], jobChan chan poolJob[T], i *uint64, abandoned <-chan struct{}) {
+	defer close(jobChan)
 	for pipe := range pipelineChan {
 		n := atomic.AddUint64(i, 1) - 1
-		m.Store(n, pipe.RunWithContext(ctx))
+		select {
+		case jobChan <- poolJob[T]{index: n, pipeline: pipe}:
+		case <-abandoned:
+			for range pipelineChan {
+			}
+			return
+		}
+	}
+}
+
+// poolWork runs the pipelines from jobChan until it is closed.
+// Pipelines that are received after abandoned has been closed are not run anymore, their result is already set to ErrAbandoned.
+func poolWork[T context.Context](runner *childRunner[T], jobChan chan poolJob[T], wg *sync.WaitGroup, m *sync.Map, abandoned <-chan struct{}) {
+	defer wg.Done()
+	for job := range jobChan {
+		select {
+		case <-abandoned:
+			continue
+		default:
+		}
+		start := time.Now()
+		err := runner.run(job.pipeline.RunWithContext)
+		m.Store(job.index, ParallelResult{Index: job.index, Err: err, Duration: time.Since(start)})
 	}
 }
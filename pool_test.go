@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -41,7 +42,7 @@ func TestNewWorkerPoolStep(t *testing.T) {
 			pipes := []*Pipeline[*testContext]{
 				NewPipeline[*testContext]().AddStep(NewStep[*testContext]("step", func(_ *testContext) error {
 					atomic.AddUint64(&counts, 1)
-					return newResult("step", tt.expectedError)
+					return newResult("step", tt.expectedError, 0)
 				})),
 			}
 			step := NewWorkerPoolStep("pool", 1, SupplierFromSlice(pipes),
@@ -105,6 +106,231 @@ func TestNewWorkerPoolStep_Cancel(t *testing.T) {
 	assert.EqualError(t, err, `step 'workerpool' failed: context deadline exceeded`)
 }
 
+func TestNewWorkerPoolStepWithOptions_GracePeriod(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	stubbornDone := make(chan struct{})
+	step := NewWorkerPoolStepWithOptions[context.Context]("pool", 2, SupplierFromSlice([]*Pipeline[context.Context]{
+		NewPipeline[context.Context]().AddStepFromFunc("stubborn", func(_ context.Context) error {
+			defer close(stubbornDone)
+			time.Sleep(200 * time.Millisecond) // ignores cancellation
+			return nil
+		}),
+		NewPipeline[context.Context]().AddStepFromFunc("cooperative", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+	}), func(_ context.Context, results map[uint64]error) error {
+		require.Len(t, results, 2)
+		abandoned := 0
+		for _, err := range results {
+			if errors.Is(err, ErrAbandoned) {
+				abandoned++
+			} else {
+				assert.EqualError(t, err, "step 'cooperative' failed: context canceled")
+			}
+		}
+		assert.Equal(t, 1, abandoned, "abandoned pipelines")
+		return nil
+	}, ParallelOptions{GracePeriod: 20 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	start := time.Now()
+	err := step.Action(ctx)
+	elapsed := time.Since(start)
+	assert.EqualError(t, err, "context canceled")
+	assert.Less(t, elapsed, 150*time.Millisecond, "step should not wait for abandoned pipeline")
+	<-stubbornDone
+}
+
+func TestNewWorkerPoolStepWithOptions_GracePeriod_DoesNotStartPendingPipelines(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	stubbornDone := make(chan struct{})
+	var started atomic.Bool
+	step := NewWorkerPoolStepWithOptions[context.Context]("pool", 1, SupplierFromSlice([]*Pipeline[context.Context]{
+		NewPipeline[context.Context]().AddStepFromFunc("stubborn", func(_ context.Context) error {
+			defer close(stubbornDone)
+			time.Sleep(100 * time.Millisecond) // ignores cancellation
+			return nil
+		}),
+		NewPipeline[context.Context]().AddStepFromFunc("pending", func(_ context.Context) error {
+			return nil
+		}).WithFinalizer(func(_ context.Context, err error) error {
+			started.Store(true) // runs even if the context is already canceled
+			return err
+		}),
+	}), func(_ context.Context, results map[uint64]error) error {
+		require.Len(t, results, 2)
+		assert.ErrorIs(t, results[0], ErrAbandoned)
+		assert.ErrorIs(t, results[1], ErrAbandoned)
+		return nil
+	}, ParallelOptions{GracePeriod: 20 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	err := step.Action(ctx)
+	assert.EqualError(t, err, "context canceled")
+	<-stubbornDone
+	time.Sleep(20 * time.Millisecond)
+	assert.False(t, started.Load(), "abandoned pipeline must not start after the step returned")
+}
+
+func TestNewWorkerPoolStepWithOptions_FailFast(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	pipes := []*Pipeline[*derivableContext]{
+		NewPipeline[*derivableContext]().AddStepFromFunc("fail", func(_ *derivableContext) error {
+			time.Sleep(5 * time.Millisecond)
+			return errors.New("boom")
+		}),
+	}
+	for i := 0; i < 3; i++ {
+		pipes = append(pipes, NewPipeline[*derivableContext]().AddStepFromFunc("wait", func(ctx *derivableContext) error {
+			assert.Equal(t, "value", ctx.field)
+			<-ctx.Done()
+			return ctx.Err()
+		}))
+	}
+	step := NewWorkerPoolStepWithOptions("pool", 2, SupplierFromSlice(pipes), func(_ *derivableContext, results map[uint64]error) error {
+		require.NotEmpty(t, results)
+		assert.EqualError(t, results[0], "step 'fail' failed: boom")
+		assert.NotErrorIs(t, results[0], ErrSiblingFailed)
+		for n := uint64(1); n < uint64(len(results)); n++ {
+			assert.ErrorIs(t, results[n], ErrSiblingFailed)
+			assert.ErrorIs(t, results[n], context.Canceled)
+		}
+		return results[0]
+	}, ParallelOptions{FailFast: true})
+	start := time.Now()
+	err := step.Action(&derivableContext{Context: context.Background(), field: "value"})
+	assert.EqualError(t, err, "step 'fail' failed: boom")
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestNewWorkerPoolStep_Panic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ran := int64(0)
+	pipes := make([]*Pipeline[context.Context], 5)
+	for i := range pipes {
+		n := i
+		pipes[i] = NewPipeline[context.Context]().AddStepFromFunc(fmt.Sprintf("job %d", n), func(_ context.Context) error {
+			if n == 1 {
+				panic("boom")
+			}
+			atomic.AddInt64(&ran, 1)
+			return nil
+		})
+	}
+	var results map[uint64]error
+	step := NewWorkerPoolStep("pool", 2, SupplierFromSlice(pipes), func(ctx context.Context, r map[uint64]error) error {
+		results = r
+		return AggregateErrors[context.Context]()(ctx, r)
+	})
+	err := step.Action(context.Background())
+	assert.EqualError(t, err, "child panicked: boom")
+	assert.ErrorIs(t, err, ErrPanic)
+	assert.Equal(t, int64(4), ran, "other children should run")
+	require.Len(t, results, 5)
+	assert.ErrorIs(t, results[1], ErrPanic)
+}
+
+func TestNewWorkerPoolStepAuto(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	assert.Equal(t, runtime.GOMAXPROCS(0), DefaultPoolSize())
+
+	ran := int64(0)
+	pipes := make([]*Pipeline[context.Context], 10)
+	for i := range pipes {
+		pipes[i] = NewPipeline[context.Context]().AddStepFromFunc("job", func(_ context.Context) error {
+			atomic.AddInt64(&ran, 1)
+			return nil
+		})
+	}
+	step := NewWorkerPoolStepAuto("pool", SupplierFromSlice(pipes), AggregateErrors[context.Context]())
+	err := NewPipeline[context.Context]().AddStep(step).RunWithContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(pipes)), ran)
+}
+
+func TestNewWorkerPoolStepWithDurations(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	pipes := []*Pipeline[context.Context]{
+		NewPipeline[context.Context]().AddStepFromFunc("slow", func(_ context.Context) error {
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		}),
+		NewPipeline[context.Context]().AddStepFromFunc("fast", func(_ context.Context) error {
+			return nil
+		}),
+	}
+	var results map[uint64]ParallelResult
+	step := NewWorkerPoolStepWithDurations("pool", 1, SupplierFromSlice(pipes), func(_ context.Context, r map[uint64]ParallelResult) error {
+		results = r
+		return nil
+	})
+	err := step.Action(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.GreaterOrEqual(t, results[0].Duration, 50*time.Millisecond)
+	assert.Less(t, results[1].Duration, 50*time.Millisecond, "waiting for a free worker is not included")
+	assert.Equal(t, uint64(1), results[1].Index)
+}
+
+func TestNewWorkerPoolStep_SupplyOrder(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	pipes := make([]*Pipeline[context.Context], 20)
+	for i := range pipes {
+		n := i
+		pipes[i] = NewPipeline[context.Context]().AddStepFromFunc(fmt.Sprintf("job %d", n), func(_ context.Context) error {
+			time.Sleep(time.Duration(20-n) * time.Millisecond) // let later pipelines finish first
+			return fmt.Errorf("job %d", n)
+		}).WithOptions(Options{DisableErrorWrapping: true})
+	}
+	step := NewWorkerPoolStep("pool", 4, SupplierFromSlice(pipes), func(_ context.Context, results map[uint64]error) error {
+		require.Len(t, results, 20)
+		for i := uint64(0); i < 20; i++ {
+			assert.EqualError(t, results[i], fmt.Sprintf("job %d", i))
+		}
+		return nil
+	})
+	err := step.Action(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestNewWorkerPoolStepWithBuffer(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	assert.PanicsWithValue(t, "buffer size cannot be lower than 1", func() {
+		NewWorkerPoolStepWithBuffer[context.Context]("pool", 1, 0, nil, nil)
+	})
+
+	supplied := make(chan struct{})
+	step := NewWorkerPoolStepWithBuffer("pool", 2, 10, func(ctx context.Context, pipelines chan *Pipeline[context.Context]) {
+		defer close(pipelines)
+		for i := 0; i < 10; i++ {
+			n := i
+			pipelines <- NewPipeline[context.Context]().AddStepFromFunc(fmt.Sprintf("job %d", n), func(_ context.Context) error {
+				<-supplied // block the workers until all pipelines are supplied
+				if n%2 == 1 {
+					return fmt.Errorf("job %d", n)
+				}
+				return nil
+			}).WithOptions(Options{DisableErrorWrapping: true})
+		}
+		close(supplied)
+	}, func(_ context.Context, results map[uint64]error) error {
+		require.Len(t, results, 10)
+		for i := uint64(0); i < 10; i++ {
+			if i%2 == 1 {
+				assert.EqualError(t, results[i], fmt.Sprintf("job %d", i))
+			} else {
+				assert.NoError(t, results[i])
+			}
+		}
+		return nil
+	})
+	err := step.Action(context.Background())
+	assert.NoError(t, err)
+}
+
 func ExampleNewWorkerPoolStep() {
 	p := NewPipeline[*testContext]()
 	pool := NewWorkerPoolStep[*testContext]("pool", 2, func(ctx *testContext, pipelines chan *Pipeline[*testContext]) {
@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"sync"
 )
 
 // Predicate is a function that expects 'true' if an ActionFunc should run.
@@ -9,6 +10,40 @@ import (
 // Predicate should be idempotent, meaning multiple invocations return the same result and without side effects.
 type Predicate[T context.Context] func(ctx T) bool
 
+// And is the method form of And, e.g. to compose predicates fluently like `p1.And(p2)`.
+func (p Predicate[T]) And(other Predicate[T]) Predicate[T] {
+	return And[T](p, other)
+}
+
+// Or is the method form of Or, e.g. to compose predicates fluently like `p1.Or(p2)`.
+func (p Predicate[T]) Or(other Predicate[T]) Predicate[T] {
+	return Or[T](p, other)
+}
+
+// Negate is the method form of Not, e.g. to compose predicates fluently like `p1.Negate().And(p2)`.
+func (p Predicate[T]) Negate() Predicate[T] {
+	return Not[T](p)
+}
+
+// DescribedPredicate is a Predicate with a human-readable description.
+// When used with Step.WhenDescribed, the description is reported as the reason to SkippedListener if the step is skipped.
+type DescribedPredicate[T context.Context] struct {
+	// Predicate is the actual Predicate to evaluate.
+	Predicate Predicate[T]
+	// Description describes the condition, e.g. "repository already cloned".
+	Description string
+}
+
+// Describe returns a DescribedPredicate with the given description and predicate.
+func Describe[T context.Context](description string, predicate Predicate[T]) DescribedPredicate[T] {
+	return DescribedPredicate[T]{Predicate: predicate, Description: description}
+}
+
+// Describe returns the description.
+func (d DescribedPredicate[T]) Describe() string {
+	return d.Description
+}
+
 // Bool returns a Predicate that simply returns v when evaluated.
 // Use BoolPtr() over Bool() if the value can change between setting up the pipeline and evaluating the predicate.
 func Bool[T context.Context](v bool) Predicate[T] {
@@ -25,6 +60,17 @@ func BoolPtr[T context.Context](v *bool) Predicate[T] {
 	}
 }
 
+// ContextBool returns a Predicate that returns the boolean value stored with the given key in a MutableContext, e.g. to let an earlier step toggle a later step.
+// It evaluates to false if the key doesn't exist or the value is not a bool.
+// Like LoadFromContext, it panics if the context has not been set up with MutableContext.
+func ContextBool[T context.Context](key any) Predicate[T] {
+	return func(ctx T) bool {
+		value, _ := LoadFromContext(ctx, key)
+		b, _ := value.(bool)
+		return b
+	}
+}
+
 // Not returns a Predicate that evaluates, but then negates the given Predicate.
 func Not[T context.Context](predicate Predicate[T]) Predicate[T] {
 	return func(ctx T) bool {
@@ -47,3 +93,64 @@ func Or[T context.Context](p1, p2 Predicate[T]) Predicate[T] {
 		return p1(ctx) || p2(ctx)
 	}
 }
+
+// AllOf returns a Predicate that does logical AND of all the given predicates.
+// The predicates are evaluated in the given order, and the evaluation stops at the first predicate that evaluates to false.
+// It evaluates to true if no predicates are given.
+func AllOf[T context.Context](predicates ...Predicate[T]) Predicate[T] {
+	return func(ctx T) bool {
+		for _, predicate := range predicates {
+			if !predicate(ctx) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// AnyOf returns a Predicate that does logical OR of all the given predicates.
+// The predicates are evaluated in the given order, and the evaluation stops at the first predicate that evaluates to true.
+// It evaluates to false if no predicates are given.
+func AnyOf[T context.Context](predicates ...Predicate[T]) Predicate[T] {
+	return func(ctx T) bool {
+		for _, predicate := range predicates {
+			if predicate(ctx) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Xor returns a Predicate that does logical XOR of the given predicates.
+// It evaluates to true if exactly one of the predicates evaluates to true.
+// Unlike And and Or, both predicates are always evaluated exactly once, as there is no short-circuit possible.
+func Xor[T context.Context](p1, p2 Predicate[T]) Predicate[T] {
+	return func(ctx T) bool {
+		return p1(ctx) != p2(ctx)
+	}
+}
+
+// Xnor returns a Predicate that does logical XNOR of the given predicates, the complement of Xor.
+// It evaluates to true if both predicates evaluate to the same value.
+// Unlike And and Or, both predicates are always evaluated exactly once, as there is no short-circuit possible.
+func Xnor[T context.Context](p1, p2 Predicate[T]) Predicate[T] {
+	return func(ctx T) bool {
+		return p1(ctx) == p2(ctx)
+	}
+}
+
+// Once returns a Predicate that evaluates the given predicate only on the first invocation and returns the cached result for all subsequent invocations, even with different contexts.
+// This is useful for expensive predicates (e.g. checking the file system or a remote API) that are evaluated multiple times, e.g. when composed with And or Or across steps.
+// Note that caching deliberately ignores the contract of Predicate to be evaluated lazily each time it's needed.
+// It is safe for concurrent use.
+func Once[T context.Context](predicate Predicate[T]) Predicate[T] {
+	var once sync.Once
+	var result bool
+	return func(ctx T) bool {
+		once.Do(func() {
+			result = predicate(ctx)
+		})
+		return result
+	}
+}
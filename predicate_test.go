@@ -64,6 +64,131 @@ func Test_Predicates(t *testing.T) {
 			expectedCounts: 1,
 			expectedResult: true,
 		},
+		"GivenAllOfPredicate_WhenEmpty_ThenExpectTrue": {
+			givenPredicate: AllOf[context.Context](),
+			expectedCounts: 0,
+			expectedResult: true,
+		},
+		"GivenAllOfPredicate_WhenAllTrue_ThenExpectTrue": {
+			givenPredicate: AllOf[context.Context](truePredicate(&counter), truePredicate(&counter), truePredicate(&counter)),
+			expectedCounts: 3,
+			expectedResult: true,
+		},
+		"GivenAllOfPredicate_WhenSecondFalse_ThenExpectFalseAndIgnoreRemaining": {
+			givenPredicate: AllOf[context.Context](truePredicate(&counter), falsePredicate(&counter), truePredicate(&counter)),
+			expectedCounts: 0,
+			expectedResult: false,
+		},
+		"GivenAnyOfPredicate_WhenEmpty_ThenExpectFalse": {
+			givenPredicate: AnyOf[context.Context](),
+			expectedCounts: 0,
+			expectedResult: false,
+		},
+		"GivenAnyOfPredicate_WhenAllFalse_ThenExpectFalse": {
+			givenPredicate: AnyOf[context.Context](falsePredicate(&counter), falsePredicate(&counter), falsePredicate(&counter)),
+			expectedCounts: -3,
+			expectedResult: false,
+		},
+		"GivenAnyOfPredicate_WhenSecondTrue_ThenExpectTrueAndIgnoreRemaining": {
+			givenPredicate: AnyOf[context.Context](falsePredicate(&counter), truePredicate(&counter), falsePredicate(&counter)),
+			expectedCounts: 0,
+			expectedResult: true,
+		},
+		"GivenXorPredicate_WhenBothFalse_ThenExpectFalse": {
+			givenPredicate: Xor[context.Context](falsePredicate(&counter), falsePredicate(&counter)),
+			expectedCounts: -2,
+			expectedResult: false,
+		},
+		"GivenXorPredicate_WhenFirstTrue_ThenExpectTrue": {
+			givenPredicate: Xor[context.Context](truePredicate(&counter), falsePredicate(&counter)),
+			expectedCounts: 0,
+			expectedResult: true,
+		},
+		"GivenXorPredicate_WhenSecondTrue_ThenExpectTrue": {
+			givenPredicate: Xor[context.Context](falsePredicate(&counter), truePredicate(&counter)),
+			expectedCounts: 0,
+			expectedResult: true,
+		},
+		"GivenXorPredicate_WhenBothTrue_ThenExpectFalseAfterBothPredicates": {
+			givenPredicate: Xor[context.Context](truePredicate(&counter), truePredicate(&counter)),
+			expectedCounts: 2,
+			expectedResult: false,
+		},
+		"GivenXnorPredicate_WhenBothFalse_ThenExpectTrue": {
+			givenPredicate: Xnor[context.Context](falsePredicate(&counter), falsePredicate(&counter)),
+			expectedCounts: -2,
+			expectedResult: true,
+		},
+		"GivenXnorPredicate_WhenFirstTrue_ThenExpectFalse": {
+			givenPredicate: Xnor[context.Context](truePredicate(&counter), falsePredicate(&counter)),
+			expectedCounts: 0,
+			expectedResult: false,
+		},
+		"GivenXnorPredicate_WhenSecondTrue_ThenExpectFalse": {
+			givenPredicate: Xnor[context.Context](falsePredicate(&counter), truePredicate(&counter)),
+			expectedCounts: 0,
+			expectedResult: false,
+		},
+		"GivenXnorPredicate_WhenBothTrue_ThenExpectTrueAfterBothPredicates": {
+			givenPredicate: Xnor[context.Context](truePredicate(&counter), truePredicate(&counter)),
+			expectedCounts: 2,
+			expectedResult: true,
+		},
+		"GivenAndMethod_WhenBothFalse_ThenExpectFalse": {
+			givenPredicate: falsePredicate(&counter).And(falsePredicate(&counter)),
+			expectedCounts: -1,
+			expectedResult: false,
+		},
+		"GivenAndMethod_WhenFirstFalse_ThenExpectFalse": {
+			givenPredicate: falsePredicate(&counter).And(truePredicate(&counter)),
+			expectedCounts: -1,
+			expectedResult: false,
+		},
+		"GivenAndMethod_WhenSecondFalse_ThenExpectFalse": {
+			givenPredicate: truePredicate(&counter).And(falsePredicate(&counter)),
+			expectedCounts: 0,
+			expectedResult: false,
+		},
+		"GivenAndMethod_WhenBothTrue_ThenExpectTrue": {
+			givenPredicate: truePredicate(&counter).And(truePredicate(&counter)),
+			expectedCounts: 2,
+			expectedResult: true,
+		},
+		"GivenOrMethod_WhenBothFalse_ThenExpectFalse": {
+			givenPredicate: falsePredicate(&counter).Or(falsePredicate(&counter)),
+			expectedCounts: -2,
+			expectedResult: false,
+		},
+		"GivenOrMethod_WhenFirstTrue_ThenExpectTrue": {
+			givenPredicate: truePredicate(&counter).Or(falsePredicate(&counter)),
+			expectedCounts: 1,
+			expectedResult: true,
+		},
+		"GivenOrMethod_WhenSecondTrue_ThenExpectTrue": {
+			givenPredicate: falsePredicate(&counter).Or(truePredicate(&counter)),
+			expectedCounts: 0,
+			expectedResult: true,
+		},
+		"GivenOrMethod_WhenBothTrue_ThenExpectTrue": {
+			givenPredicate: truePredicate(&counter).Or(truePredicate(&counter)),
+			expectedCounts: 1,
+			expectedResult: true,
+		},
+		"GivenNegateMethod_WhenFalse_ThenExpectTrue": {
+			givenPredicate: falsePredicate(&counter).Negate(),
+			expectedCounts: -1,
+			expectedResult: true,
+		},
+		"GivenNegateMethod_WhenTrue_ThenExpectFalse": {
+			givenPredicate: truePredicate(&counter).Negate(),
+			expectedCounts: 1,
+			expectedResult: false,
+		},
+		"GivenChainedMethods_WhenNegatedFalseAndTrue_ThenExpectTrue": {
+			givenPredicate: falsePredicate(&counter).Negate().And(truePredicate(&counter)),
+			expectedCounts: 0,
+			expectedResult: true,
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -91,6 +216,47 @@ func TestBoolPtr(t *testing.T) {
 	assert.True(t, called)
 }
 
+func TestContextBool(t *testing.T) {
+	var calls []string
+	p := NewPipeline[context.Context]()
+	p.WithSteps(
+		p.NewStep("enable", func(ctx context.Context) error {
+			StoreInContext(ctx, "enabled", true)
+			StoreInContext(ctx, "not a bool", "true")
+			return nil
+		}),
+		p.When(ContextBool[context.Context]("enabled"), "gated", func(_ context.Context) error {
+			calls = append(calls, "gated")
+			return nil
+		}),
+		p.When(ContextBool[context.Context]("unknown"), "absent", func(_ context.Context) error {
+			calls = append(calls, "absent")
+			return nil
+		}),
+		p.When(ContextBool[context.Context]("not a bool"), "not a bool", func(_ context.Context) error {
+			calls = append(calls, "not a bool")
+			return nil
+		}),
+	)
+	err := p.RunWithContext(MutableContext(context.Background()))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gated"}, calls)
+}
+
+func TestOnce(t *testing.T) {
+	counter := 0
+	once := Once(truePredicate(&counter))
+	for i := 0; i < 3; i++ {
+		assert.True(t, once(context.Background()), "invocation %d", i)
+	}
+	assert.Equal(t, 1, counter)
+
+	counter = 0
+	once = Once(falsePredicate(&counter))
+	assert.False(t, Or(once, once)(context.Background()))
+	assert.Equal(t, -1, counter)
+}
+
 func truePredicate(counter *int) Predicate[context.Context] {
 	return func(_ context.Context) bool {
 		*counter++
@@ -0,0 +1,34 @@
+// Package predicates provides common pipeline.Predicate implementations that read the state of the file system and environment.
+// The predicates are evaluated each time they are called and have no side effects.
+package predicates
+
+import (
+	"context"
+	"os"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+)
+
+// FileExists returns a pipeline.Predicate that returns true if the given path exists and is not a directory.
+func FileExists[T context.Context](path string) pipeline.Predicate[T] {
+	return func(_ T) bool {
+		info, err := os.Stat(path)
+		return err == nil && !info.IsDir()
+	}
+}
+
+// DirExists returns a pipeline.Predicate that returns true if the given path exists and is a directory.
+func DirExists[T context.Context](path string) pipeline.Predicate[T] {
+	return func(_ T) bool {
+		info, err := os.Stat(path)
+		return err == nil && info.IsDir()
+	}
+}
+
+// EnvSet returns a pipeline.Predicate that returns true if the environment variable with the given name is set, even if its value is empty.
+func EnvSet[T context.Context](name string) pipeline.Predicate[T] {
+	return func(_ T) bool {
+		_, set := os.LookupEnv(name)
+		return set
+	}
+}
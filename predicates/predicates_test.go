@@ -0,0 +1,40 @@
+package predicates
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileExists(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, []byte{}, 0o600))
+
+	assert.True(t, FileExists[context.Context](file)(context.Background()))
+	assert.False(t, FileExists[context.Context](dir)(context.Background()), "directory")
+	assert.False(t, FileExists[context.Context](filepath.Join(dir, "missing"))(context.Background()))
+}
+
+func TestDirExists(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, []byte{}, 0o600))
+
+	assert.True(t, DirExists[context.Context](dir)(context.Background()))
+	assert.False(t, DirExists[context.Context](file)(context.Background()), "file")
+	assert.False(t, DirExists[context.Context](filepath.Join(dir, "missing"))(context.Background()))
+}
+
+func TestEnvSet(t *testing.T) {
+	t.Setenv("PIPELINE_TEST_SET", "value")
+	t.Setenv("PIPELINE_TEST_EMPTY", "")
+
+	assert.True(t, EnvSet[context.Context]("PIPELINE_TEST_SET")(context.Background()))
+	assert.True(t, EnvSet[context.Context]("PIPELINE_TEST_EMPTY")(context.Background()))
+	assert.False(t, EnvSet[context.Context]("PIPELINE_TEST_UNSET")(context.Background()))
+}
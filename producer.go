@@ -0,0 +1,38 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+)
+
+// NewProducerStep returns a new Step that runs the given function and stores the returned value in the context under the given key.
+// The value is only stored if fn returns no error.
+// The context has to be set up with MutableContext first, see also NewMutablePipeline.
+// Use ConsumeFromContext to retrieve the value in subsequent steps.
+func NewProducerStep[T context.Context, V any](name string, key any, fn func(ctx T) (V, error)) Step[T] {
+	return NewStep[T](name, func(ctx T) error {
+		value, err := fn(ctx)
+		if err != nil {
+			return err
+		}
+		StoreInContext(ctx, key, value)
+		return nil
+	})
+}
+
+// ConsumeFromContext returns the value stored under the given key, e.g. by a step created with NewProducerStep.
+// Unlike LoadTyped, it returns an error if the key doesn't exist or if the value is not of type V, so that it can be returned directly from an ActionFunc.
+//
+// Note: This method is thread-safe, but panics if the ctx has not been set up with MutableContext first.
+func ConsumeFromContext[V any](ctx context.Context, key any) (V, error) {
+	val, found := LoadFromContext(ctx, key)
+	if !found {
+		var zero V
+		return zero, fmt.Errorf("key %v was not found in context", key)
+	}
+	v, ok := val.(V)
+	if !ok {
+		return v, fmt.Errorf("value of key %v is of type %T, but %T is required", key, val, v)
+	}
+	return v, nil
+}
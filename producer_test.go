@@ -0,0 +1,86 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProducerStep(t *testing.T) {
+	tests := map[string]struct {
+		givenError    error
+		expectedError string
+		expectedFound bool
+	}{
+		"GivenSuccessfulProducer_ThenStoreValue": {
+			expectedFound: true,
+		},
+		"GivenFailingProducer_ThenDontStoreValue": {
+			givenError:    errors.New("failed"),
+			expectedError: "step 'produce' failed: failed",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := MutableContext(context.Background())
+			p := NewPipeline[context.Context]().AddStep(NewProducerStep("produce", "key", func(_ context.Context) (int, error) {
+				return 42, tc.givenError
+			}))
+			err := p.RunWithContext(ctx)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			_, found := LoadFromContext(ctx, "key")
+			assert.Equal(t, tc.expectedFound, found)
+		})
+	}
+}
+
+func TestConsumeFromContext(t *testing.T) {
+	ctx := MutableContext(context.Background())
+	StoreInContext(ctx, "key", 42)
+
+	value, err := ConsumeFromContext[int](ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, value)
+
+	_, err = ConsumeFromContext[string](ctx, "key")
+	assert.EqualError(t, err, `value of key key is of type int, but string is required`)
+
+	_, err = ConsumeFromContext[int](ctx, "unknown")
+	assert.EqualError(t, err, `key unknown was not found in context`)
+
+	_, err = ConsumeFromContext[int](ctx, 1)
+	assert.EqualError(t, err, `key 1 was not found in context`)
+
+	type structKey struct{ name string }
+	StoreInContext(ctx, structKey{name: "config"}, "value")
+	_, err = ConsumeFromContext[int](ctx, structKey{name: "config"})
+	assert.EqualError(t, err, `value of key {config} is of type string, but int is required`)
+}
+
+func ExampleNewProducerStep() {
+	type configKey struct{}
+
+	p := NewMutablePipeline[context.Context]()
+	p.WithSteps(
+		NewProducerStep("load config", configKey{}, func(_ context.Context) (map[string]string, error) {
+			return map[string]string{"color": "blue"}, nil
+		}),
+		p.NewStep("use config", func(ctx context.Context) error {
+			config, err := ConsumeFromContext[map[string]string](ctx, configKey{})
+			if err != nil {
+				return err
+			}
+			fmt.Println(config["color"])
+			return nil
+		}),
+	)
+	_ = p.RunWithContext(context.Background())
+	// Output: blue
+}
@@ -0,0 +1,102 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+)
+
+// ProgressReporter estimates the completion of a pipeline run based on the weight of the steps that have completed so far.
+// The weight of a step is Step.Weight if it's positive.
+// Otherwise, it's estimated from the duration that has been recorded for a step with the same name in a prior run, relative to the average recorded duration.
+// Steps that have neither a weight nor a recorded duration have a weight of 1, so that without any weights and history each step weighs the same.
+// Its methods are safe for concurrent use.
+type ProgressReporter[T context.Context] struct {
+	// OnProgress is an optional callback that is invoked with the new progress each time a step completes.
+	// It's not called concurrently.
+	OnProgress func(progress float64)
+
+	weights   map[string]float64
+	total     float64
+	completed map[string]bool
+	progress  float64
+	mu        sync.Mutex
+}
+
+// NewProgressReporter returns a new ProgressReporter for the steps of the given pipeline.
+// The given DependencyRecorder provides the durations of a prior run and is optional.
+// Steps added to the pipeline after the reporter has been created aren't considered, neither are the steps within nested pipelines.
+func NewProgressReporter[T context.Context](p *Pipeline[T], history *DependencyRecorder[T]) *ProgressReporter[T] {
+	durations := historicalDurations(history)
+	average := 0.0
+	for _, d := range durations {
+		average += d / float64(len(durations))
+	}
+	r := &ProgressReporter[T]{weights: make(map[string]float64, len(p.steps)), completed: map[string]bool{}}
+	for _, step := range p.steps {
+		if _, exists := r.weights[step.Name]; exists {
+			continue
+		}
+		weight := 1.0
+		if d, recorded := durations[step.Name]; step.Weight > 0 {
+			weight = step.Weight
+		} else if recorded && average > 0 {
+			weight = d / average
+		}
+		r.weights[step.Name] = weight
+		r.total += weight
+	}
+	return r
+}
+
+// historicalDurations returns the most recent non-zero duration for each step name in the given recorder.
+func historicalDurations[T context.Context](history *DependencyRecorder[T]) map[string]float64 {
+	durations := map[string]float64{}
+	if history == nil {
+		return durations
+	}
+	history.mu.Lock()
+	defer history.mu.Unlock()
+	for i, step := range history.Records {
+		if i < len(history.Durations) && history.Durations[i] > 0 {
+			durations[step.Name] = float64(history.Durations[i])
+		}
+	}
+	return durations
+}
+
+// RecordResult marks the given step as completed, regardless of the error.
+// It is a ResultListener that is meant to be used as after hook, see Pipeline.WithProgressReporter.
+func (r *ProgressReporter[T]) RecordResult(step Step[T], _ error) {
+	r.complete(step)
+}
+
+// RecordSkipped marks the given step as completed, since it won't run anymore.
+// It is a SkippedListener that is meant to be used as skipped hook, see Pipeline.WithProgressReporter.
+func (r *ProgressReporter[T]) RecordSkipped(step Step[T], _ string) {
+	r.complete(step)
+}
+
+func (r *ProgressReporter[T]) complete(step Step[T]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	weight, known := r.weights[step.Name]
+	if !known || r.completed[step.Name] {
+		return
+	}
+	r.completed[step.Name] = true
+	r.progress += weight / r.total
+	if r.progress > 1 || len(r.completed) == len(r.weights) {
+		r.progress = 1
+	}
+	if r.OnProgress != nil {
+		r.OnProgress(r.progress)
+	}
+}
+
+// Progress returns the estimated completion between 0 and 1, computed as the weight of the completed steps over the total weight.
+// Steps that run multiple times, e.g. due to Jump, count only once.
+func (r *ProgressReporter[T]) Progress() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.progress
+}
@@ -0,0 +1,60 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressReporter(t *testing.T) {
+	t.Run("GivenWeightedSteps_ThenReportCompletedWeight", func(t *testing.T) {
+		var progress []float64
+		p := NewPipeline[context.Context]()
+		var reporter *ProgressReporter[context.Context]
+		record := func(_ context.Context) error {
+			progress = append(progress, reporter.Progress())
+			return nil
+		}
+		p.WithSteps(
+			p.NewStep("light", record).WithWeight(1),
+			p.NewStep("medium", record).WithWeight(2),
+			p.NewStep("heavy", record).WithWeight(5),
+		)
+		reporter = NewProgressReporter(p, nil)
+		reporter.OnProgress = func(value float64) {
+			progress = append(progress, value)
+		}
+		err := p.WithProgressReporter(reporter).RunWithContext(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{0, 0.125, 0.125, 0.375, 0.375, 1}, progress)
+		assert.Equal(t, float64(1), reporter.Progress())
+	})
+	t.Run("GivenHistory_ThenEstimateWeightFromDurations", func(t *testing.T) {
+		history := NewDependencyRecorder[context.Context]()
+		history.Records = []Step[context.Context]{{Name: "short"}, {Name: "long"}}
+		history.Durations = []time.Duration{time.Second, 3 * time.Second}
+
+		p := NewPipeline[context.Context]()
+		p.WithSteps(
+			p.NewStep("short", failingAction).When(Bool[context.Context](false)),
+			p.NewStep("long", failingAction).When(Bool[context.Context](false)),
+			p.NewStep("unknown", failingAction).When(Bool[context.Context](false)),
+		)
+		var progress []float64
+		reporter := NewProgressReporter(p, history)
+		reporter.OnProgress = func(value float64) {
+			progress = append(progress, value)
+		}
+		err := p.WithProgressReporter(reporter).RunWithContext(context.Background())
+		assert.NoError(t, err)
+		// The average duration is 2s, hence the weights are 0.5, 1.5 and 1.
+		assert.Equal(t, []float64{1.0 / 6, 4.0 / 6, 1}, progress)
+	})
+	t.Run("GivenNoSteps_ThenReportZero", func(t *testing.T) {
+		reporter := NewProgressReporter(NewPipeline[context.Context](), nil)
+		reporter.RecordResult(NewStep("unknown", failingAction), nil)
+		assert.Equal(t, float64(0), reporter.Progress())
+	})
+}
@@ -0,0 +1,57 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// NewRaceStep creates a pipeline step that runs nested pipelines in their own Go routines and succeeds as soon as one of them succeeds, e.g. to query redundant providers.
+// The function provided as Supplier is expected to close the given channel when no more pipelines should be executed, otherwise this step blocks forever.
+//
+// The supplied pipelines share a context derived from the step's context, which gets canceled once the first pipeline succeeds.
+// This also stops the Supplier from supplying more pipelines, and pipelines that are still supplied afterwards are not run anymore.
+// The step returns once the remaining child pipelines have returned, so they should listen for context.Done() to stop early.
+// If all child pipelines fail, their errors are combined with AggregateErrors.
+// This step requires T to implement ContextDeriver, unless T is context.Context itself.
+func NewRaceStep[T context.Context](name string, pipelineSupplier Supplier[T]) Step[T] {
+	step := Step[T]{Name: name}
+	step.Action = func(ctx T) error {
+		raceCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		childCtx := deriveContext(ctx, raceCtx)
+		pipelineChan := make(chan *Pipeline[T])
+		m := sync.Map{}
+		var wg sync.WaitGroup
+		var won atomic.Bool
+		i := uint64(0)
+
+		go pipelineSupplier(childCtx, pipelineChan)
+		for pipe := range pipelineChan {
+			if won.Load() {
+				continue // drain the channel so that the supplier can return
+			}
+			p := pipe
+			wg.Add(1)
+			n := i
+			i++
+			go func() {
+				defer wg.Done()
+				start := time.Now()
+				err := p.RunWithContext(childCtx)
+				if err == nil && won.CompareAndSwap(false, true) {
+					cancel()
+				}
+				m.Store(n, ParallelResult{Index: n, Err: err, Duration: time.Since(start)})
+			}()
+		}
+		wg.Wait()
+		if won.Load() {
+			return nil
+		}
+		res := collectResults(ctx, AggregateErrors[T](), &m)
+		return setResultErrorFromContext(ctx, name, res)
+	}
+	return step
+}
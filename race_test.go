@@ -0,0 +1,74 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestNewRaceStep(t *testing.T) {
+	tests := map[string]struct {
+		givenPipelines []*Pipeline[context.Context]
+		expectedError  string
+	}{
+		"GivenSecondPipelineSucceeds_ThenCancelSiblingsAndReturnNil": {
+			givenPipelines: []*Pipeline[context.Context]{
+				NewPipeline[context.Context]().AddStepFromFunc("slow", func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}),
+				NewPipeline[context.Context]().AddStepFromFunc("fast", func(_ context.Context) error {
+					time.Sleep(5 * time.Millisecond)
+					return nil
+				}),
+				NewPipeline[context.Context]().AddStepFromFunc("failing", func(_ context.Context) error {
+					return errors.New("unavailable")
+				}),
+			},
+		},
+		"GivenAllPipelinesFail_ThenReturnAggregatedError": {
+			givenPipelines: []*Pipeline[context.Context]{
+				NewPipeline[context.Context]().AddStepFromFunc("first", func(_ context.Context) error {
+					return errors.New("unavailable")
+				}),
+				NewPipeline[context.Context]().AddStepFromFunc("second", func(_ context.Context) error {
+					time.Sleep(5 * time.Millisecond)
+					return errors.New("timeout")
+				}),
+			},
+			expectedError: "step 'first' failed: unavailable\nstep 'second' failed: timeout",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+			step := NewRaceStep("race", SupplierFromSlice(tc.givenPipelines))
+			start := time.Now()
+			err := step.Action(context.Background())
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Less(t, time.Since(start), 500*time.Millisecond)
+		})
+	}
+}
+
+func TestNewRaceStep_Cancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	step := NewRaceStep("race", SupplierFromSlice([]*Pipeline[context.Context]{
+		NewPipeline[context.Context]().AddStepFromFunc("slow", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+	}))
+	err := step.Action(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
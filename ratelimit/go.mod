@@ -0,0 +1,17 @@
+module github.com/ccremer/go-command-pipeline/ratelimit
+
+go 1.23
+
+require (
+	github.com/ccremer/go-command-pipeline v0.0.0
+	github.com/stretchr/testify v1.8.3
+	golang.org/x/time v0.5.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/ccremer/go-command-pipeline => ../
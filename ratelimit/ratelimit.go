@@ -0,0 +1,23 @@
+package ratelimit
+
+import (
+	"context"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"golang.org/x/time/rate"
+)
+
+// WithRateLimit returns a copy of the given step whose Action waits for the given limiter before it runs, e.g. to throttle calls to a downstream API.
+// The limiter can be shared between steps and pipelines, which is especially useful for child pipelines of pipeline.NewFanOutStep.
+// If the context is canceled while waiting (or the wait would exceed its deadline), the action doesn't run and the error of rate.Limiter.Wait is returned.
+// Since Go doesn't support methods in other packages, this is a function rather than a method of pipeline.Step.
+func WithRateLimit[T context.Context](step pipeline.Step[T], limiter *rate.Limiter) pipeline.Step[T] {
+	action := step.Action
+	step.Action = func(ctx T) error {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+		return action(ctx)
+	}
+	return step
+}
@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	var calls int64
+	limiter := rate.NewLimiter(rate.Every(10*time.Millisecond), 1)
+	step := WithRateLimit(pipeline.NewStep("limited", func(_ context.Context) error {
+		atomic.AddInt64(&calls, 1)
+		return nil
+	}), limiter)
+
+	p := pipeline.NewPipeline[context.Context]()
+	for i := 0; i < 5; i++ {
+		p.AddStep(step)
+	}
+	start := time.Now()
+	err := p.RunWithContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), calls)
+	// the first call is allowed by the burst, the remaining 4 calls are throttled
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestWithRateLimit_Cancel(t *testing.T) {
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	limiter.Allow() // exhaust burst
+	called := false
+	step := WithRateLimit(pipeline.NewStep("limited", func(_ context.Context) error {
+		called = true
+		return nil
+	}), limiter)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := step.Action(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, called)
+}
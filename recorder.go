@@ -2,10 +2,13 @@ package pipeline
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Recorder Records the steps executed in a pipeline.
@@ -29,29 +32,137 @@ type DependencyResolver[T context.Context] interface {
 	RequireDependencyByFuncName(actions ...ActionFunc[T]) error
 	// MustRequireDependencyByFuncName is RequireDependencyByFuncName but any non-nil errors result in a panic.
 	MustRequireDependencyByFuncName(actions ...ActionFunc[T])
+	// RequireDependencyByActionID checks if any of the given action IDs are present in the Records.
+	// It returns nil if all given IDs are in the Records in any order.
+	// See Step.WithActionID.
+	RequireDependencyByActionID(ids ...string) error
+	// MustRequireDependencyByActionID is RequireDependencyByActionID but any non-nil errors result in a panic.
+	MustRequireDependencyByActionID(ids ...string)
+	// RequireDependencyBefore checks if the step with the name given as before is present in the Records before the step with the name given as after.
+	// It returns an error if either step is missing, or if after has been recorded before before.
+	RequireDependencyBefore(before, after string) error
+	// MustRequireDependencyBefore is RequireDependencyBefore but any non-nil errors result in a panic.
+	MustRequireDependencyBefore(before, after string)
 }
 
 // DependencyRecorder is a Recorder and DependencyResolver that tracks each Step executed and can be used to query if certain steps are in the Records.
+// Its methods are safe for concurrent use, e.g. if the recorder is used as hook in child pipelines of NewFanOutStep or NewWorkerPoolStep.
+// The Durations are attributed to the correct Records in that case as well, since RecordResult matches each finished step with its own run.
+// Accessing the fields directly is not synchronized though, so they should only be read once the pipeline has finished.
+// The zero value is ready to use.
 type DependencyRecorder[T context.Context] struct {
 	// Records contains a slice of Steps that were run.
 	// It contains also the last Step that failed with an error.
 	Records []Step[T]
+	// Durations contains the duration of each Step in Records at the same index.
+	// The durations are only measured if RecordResult is used as after hook as well, see Pipeline.WithDependencyRecorder.
+	// A Step that hasn't finished yet has a zero duration.
+	Durations []time.Duration
+	// Skipped contains a slice of Steps that didn't run, e.g. because their Step.Condition evaluated to false.
+	// The Steps are only recorded if RecordSkipped is used as skipped hook, see Pipeline.WithDependencyRecorder.
+	Skipped []Step[T]
+	// SkipReasons contains the reason why each Step in Skipped didn't run at the same index.
+	SkipReasons []string
+
+	// runs contains the start of each Step in Records at the same index, so that RecordResult can measure the duration.
+	runs []recordedRun
+	mu   sync.Mutex
+}
+
+type recordedRun struct {
+	start      time.Time
+	invocation uint64
+	finished   bool
 }
 
 // NewDependencyRecorder returns a new instance of DependencyRecorder.
 func NewDependencyRecorder[T context.Context]() *DependencyRecorder[T] {
-	return &DependencyRecorder[T]{Records: []Step[T]{}}
+	return &DependencyRecorder[T]{Records: []Step[T]{}, Durations: []time.Duration{}, Skipped: []Step[T]{}, SkipReasons: []string{}}
 }
 
 // Record implements Recorder.
 func (s *DependencyRecorder[T]) Record(step Step[T]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Records = append(s.Records, step)
+	for len(s.Durations) < len(s.Records) {
+		s.Durations = append(s.Durations, 0)
+	}
+	for len(s.runs) < len(s.Records)-1 {
+		// records that have been set without Record have no start
+		s.runs = append(s.runs, recordedRun{finished: true})
+	}
+	s.runs = append(s.runs, recordedRun{start: time.Now(), invocation: step.invocation})
+}
+
+// RecordResult measures the duration of the given Step, which has been recorded with Record and is not yet finished.
+// It is a ResultListener that is meant to be used as after hook in combination with Record as before hook.
+// Each run of a step by a Pipeline is matched exactly, even if steps run concurrently, e.g. in child pipelines of NewFanOutStep.
+// Steps that haven't been run by a Pipeline are matched by name with the most recently recorded, unfinished Step.
+func (s *DependencyRecorder[T]) RecordResult(step Step[T], _ error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		run := &s.runs[i]
+		if run.finished {
+			continue
+		}
+		if (step.invocation != 0 && run.invocation == step.invocation) || (step.invocation == 0 && s.Records[i].Name == step.Name) {
+			run.finished = true
+			s.Durations[i] = time.Since(run.start)
+			return
+		}
+	}
+}
+
+// RecordSkipped adds the step and the reason to the Skipped steps, distinct from the Records of steps that ran.
+// It is a SkippedListener that is meant to be used as skipped hook, see Pipeline.WithSkippedHooks.
+func (s *DependencyRecorder[T]) RecordSkipped(step Step[T], reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Skipped = append(s.Skipped, step)
+	s.SkipReasons = append(s.SkipReasons, reason)
+}
+
+// TotalDuration returns the sum of all Durations.
+// Note that the steps of nested pipelines are recorded as well, so their durations are also included in the duration of the parent step.
+func (s *DependencyRecorder[T]) TotalDuration() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	total := time.Duration(0)
+	for _, d := range s.Durations {
+		total += d
+	}
+	return total
+}
+
+// Reset clears the Records and all related state like Durations and Skipped steps, e.g. to reuse the recorder for another pipeline run.
+func (s *DependencyRecorder[T]) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Records = []Step[T]{}
+	s.Durations = []time.Duration{}
+	s.Skipped = []Step[T]{}
+	s.SkipReasons = []string{}
+	s.runs = nil
+}
+
+// Snapshot returns a copy of the Records.
+// Unlike accessing Records directly, it is safe to call while a pipeline is running.
+func (s *DependencyRecorder[T]) Snapshot() []Step[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := make([]Step[T], len(s.Records))
+	copy(snapshot, s.Records)
+	return snapshot
 }
 
 // RequireDependencyByStepName implements DependencyResolver.RequireDependencyByStepName.
 // A DependencyError is returned with a list of names that aren't in the Records.
 // Steps that share the same name are not distinguishable.
 func (s *DependencyRecorder[T]) RequireDependencyByStepName(stepNames ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if len(stepNames) == 0 {
 		return nil
 	}
@@ -115,15 +226,17 @@ func (s *DependencyRecorder[T]) MustRequireDependencyByStepName(stepNames ...str
 //  ...
 //  recorder.RequireDependencyByFuncName(genFunc()) // works
 func (s *DependencyRecorder[T]) RequireDependencyByFuncName(actions ...ActionFunc[T]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if len(actions) == 0 {
 		return nil
 	}
 	missing := make([]string, 0)
 	for _, desiredAction := range actions {
 		found := false
-		desiredActionName := getFunctionName(desiredAction)
+		desiredActionName := FuncName(desiredAction)
 		for _, step := range s.Records {
-			actionName := getFunctionName(step.Action)
+			actionName := FuncName(step.Action)
 			if actionName == desiredActionName {
 				found = true
 				break
@@ -147,7 +260,109 @@ func (s *DependencyRecorder[T]) MustRequireDependencyByFuncName(actions ...Actio
 	}
 }
 
-func getFunctionName(temp interface{}) string {
+// RequireDependencyByActionID implements DependencyResolver.RequireDependencyByActionID.
+// A DependencyError is returned with a list of IDs that aren't in the Records.
+//
+// Unlike RequireDependencyByFuncName, this also works reliably with generated functions:
+//
+//	pipe.AddStep(pipe.NewStep("test", generateFunc()).WithActionID("generated"))
+//	...
+//	recorder.RequireDependencyByActionID("generated") // works
+func (s *DependencyRecorder[T]) RequireDependencyByActionID(ids ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(ids) == 0 {
+		return nil
+	}
+	missing := make([]string, 0)
+	for _, desiredID := range ids {
+		found := false
+		for _, step := range s.Records {
+			if step.ActionID != "" && step.ActionID == desiredID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, desiredID)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w", &DependencyError{MissingSteps: missing})
+}
+
+// MustRequireDependencyByActionID implements DependencyResolver.MustRequireDependencyByActionID.
+func (s *DependencyRecorder[T]) MustRequireDependencyByActionID(ids ...string) {
+	err := s.RequireDependencyByActionID(ids...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// RequireDependencyBefore implements DependencyResolver.RequireDependencyBefore.
+// A DependencyError is returned with a list of names that aren't in the Records.
+// Steps that share the same name are not distinguishable, hence the first occurrence of each name is compared.
+func (s *DependencyRecorder[T]) RequireDependencyBefore(before, after string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	beforeIndex, afterIndex := -1, -1
+	for i := len(s.Records) - 1; i >= 0; i-- {
+		if s.Records[i].Name == before {
+			beforeIndex = i
+		}
+		if s.Records[i].Name == after {
+			afterIndex = i
+		}
+	}
+	missing := make([]string, 0)
+	if beforeIndex < 0 {
+		missing = append(missing, before)
+	}
+	if afterIndex < 0 {
+		missing = append(missing, after)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w", &DependencyError{MissingSteps: missing})
+	}
+	if afterIndex < beforeIndex {
+		return fmt.Errorf("step %q ran before its dependency %q", after, before)
+	}
+	return nil
+}
+
+// MustRequireDependencyBefore implements DependencyResolver.MustRequireDependencyBefore.
+func (s *DependencyRecorder[T]) MustRequireDependencyBefore(before, after string) {
+	err := s.RequireDependencyBefore(before, after)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// recordJSON is the serializable view of a recorded Step.
+type recordJSON struct {
+	Name string `json:"name"`
+	Func string `json:"func"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// It returns the Records as an array of objects with the step's name and the name of its action function, resolved as in RequireDependencyByFuncName.
+func (s *DependencyRecorder[T]) MarshalJSON() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := make([]recordJSON, len(s.Records))
+	for i, step := range s.Records {
+		records[i] = recordJSON{Name: step.Name, Func: FuncName(step.Action)}
+	}
+	return json.Marshal(records)
+}
+
+// FuncName returns the fully-qualified name of the given function as resolved by the Go runtime, e.g. "github.com/org/repo/pkg.MyFunc".
+// It's the name that RequireDependencyByFuncName compares, and it can be used to name steps consistently after their action.
+// Note that generated functions and closures get a generated name like "pkg.generateFunc.func1", which isn't unique across multiple invocations of generateFunc.
+// It panics if the given value is not a function.
+func FuncName(temp interface{}) string {
 	value := reflect.ValueOf(temp)
 	if value.Kind() != reflect.Func {
 		panic(fmt.Errorf("given value is not a function: %v", temp))
@@ -158,11 +373,11 @@ func getFunctionName(temp interface{}) string {
 
 // DependencyError is an error that indicates which steps did not satisfy dependency requirements.
 type DependencyError struct {
-	// MissingSteps returns a slice of Step or ActionFunc names.
+	// MissingSteps returns a slice of Step names, ActionFunc names or action IDs.
 	MissingSteps []string
 }
 
-// Error returns a stringed list of steps that did not run either by Step or ActionFunc name.
+// Error returns a stringed list of steps that did not run either by Step name, ActionFunc name or action ID.
 func (d *DependencyError) Error() string {
 	joined := strings.Join(d.MissingSteps, ", ")
 	return fmt.Sprintf("required steps did not run: [%s]", joined)
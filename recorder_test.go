@@ -2,10 +2,13 @@ package pipeline
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDependencyRecorder_ImplementsInterface(t *testing.T) {
@@ -77,6 +80,46 @@ func TestDependencyRecorder_RequireByStepName(t *testing.T) {
 	}
 }
 
+func TestDependencyRecorder_RequireDependencyBefore(t *testing.T) {
+	tests := map[string]struct {
+		givenRecordedSteps []Step[context.Context]
+		expectedError      string
+	}{
+		"GivenCorrectOrder_ThenReturnNil": {
+			givenRecordedSteps: []Step[context.Context]{newTestStep("create client"), newTestStep("other"), newTestStep("connect")},
+		},
+		"GivenReversedOrder_ThenReturnError": {
+			givenRecordedSteps: []Step[context.Context]{newTestStep("connect"), newTestStep("create client")},
+			expectedError:      `step "connect" ran before its dependency "create client"`,
+		},
+		"GivenDuplicateSteps_ThenCompareFirstOccurrence": {
+			givenRecordedSteps: []Step[context.Context]{newTestStep("create client"), newTestStep("connect"), newTestStep("create client")},
+		},
+		"GivenDependencyMissing_ThenReturnError": {
+			givenRecordedSteps: []Step[context.Context]{newTestStep("connect")},
+			expectedError:      "required steps did not run: [create client]",
+		},
+		"GivenBothMissing_ThenReturnError": {
+			givenRecordedSteps: []Step[context.Context]{},
+			expectedError:      "required steps did not run: [create client, connect]",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := DependencyRecorder[context.Context]{Records: tc.givenRecordedSteps}
+			err := recorder.RequireDependencyBefore("create client", "connect")
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Panics(t, func() {
+					recorder.MustRequireDependencyBefore("create client", "connect")
+				})
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestDependencyRecorder_RequireDependencyByFuncName(t *testing.T) {
 	tests := map[string]struct {
 		givenRecordedSteps   []Step[context.Context]
@@ -132,6 +175,75 @@ func TestDependencyRecorder_RequireDependencyByFuncName(t *testing.T) {
 	}
 }
 
+func TestDependencyRecorder_RequireDependencyByActionID(t *testing.T) {
+	generateFunc := func() ActionFunc[context.Context] {
+		return func(_ context.Context) error {
+			return nil
+		}
+	}
+	tests := map[string]struct {
+		givenRecordedSteps []Step[context.Context]
+		givenRequiredIDs   []string
+		expectedError      string
+	}{
+		"GivenNoIDs_ThenReturnNil": {
+			givenRecordedSteps: []Step[context.Context]{newTestStep("step 1").WithActionID("id1")},
+			givenRequiredIDs:   []string{},
+		},
+		"GivenNoStepsRecorded_ThenReturnError": {
+			givenRecordedSteps: []Step[context.Context]{},
+			givenRequiredIDs:   []string{"id1"},
+			expectedError:      "required steps did not run: [id1]",
+		},
+		"GivenStepsWithoutID_WhenRequiringEmptyID_ThenReturnError": {
+			givenRecordedSteps: []Step[context.Context]{newTestStep("step 1")},
+			givenRequiredIDs:   []string{""},
+			expectedError:      "required steps did not run: []",
+		},
+		"GivenGeneratedClosures_WhenOneMissing_ThenReturnError": {
+			givenRecordedSteps: []Step[context.Context]{NewStep("step 1", generateFunc()).WithActionID("id1")},
+			givenRequiredIDs:   []string{"id1", "id2"},
+			expectedError:      "required steps did not run: [id2]",
+		},
+		"GivenGeneratedClosures_WhenNoneMissing_ThenReturnNil": {
+			givenRecordedSteps: []Step[context.Context]{
+				NewStep("step 1", generateFunc()).WithActionID("id1"),
+				NewStep("step 2", generateFunc()).WithActionID("id2"),
+			},
+			givenRequiredIDs: []string{"id2", "id1"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := DependencyRecorder[context.Context]{Records: tc.givenRecordedSteps}
+			err := recorder.RequireDependencyByActionID(tc.givenRequiredIDs...)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+	t.Run("GivenGeneratedClosures_WhenResolvingByFuncName_ThenClosuresAreIndistinguishable", func(t *testing.T) {
+		first, second := generateFunc(), generateFunc()
+		recorder := DependencyRecorder[context.Context]{Records: []Step[context.Context]{NewStep("step 1", first).WithActionID("id1")}}
+		assert.NoError(t, recorder.RequireDependencyByFuncName(second), "func name resolution cannot tell closures apart")
+		assert.EqualError(t, recorder.RequireDependencyByActionID("id2"), "required steps did not run: [id2]")
+	})
+}
+
+func TestDependencyRecorder_MustRequireDependencyByActionID(t *testing.T) {
+	assert.PanicsWithError(t, "required steps did not run: [id]", func() {
+		recorder := NewDependencyRecorder[context.Context]()
+		recorder.MustRequireDependencyByActionID("id")
+	})
+	assert.NotPanics(t, func() {
+		recorder := NewDependencyRecorder[context.Context]()
+		recorder.Record(newTestStep("test").WithActionID("id"))
+		recorder.MustRequireDependencyByActionID("id")
+	})
+}
+
 func TestDependencyRecorder_MustRequireDependencyByStepName(t *testing.T) {
 	assert.PanicsWithError(t, "required steps did not run: [test]", func() {
 		recorder := NewDependencyRecorder[context.Context]()
@@ -158,6 +270,185 @@ func TestDependencyRecorder_MustRequireDependencyByFuncName(t *testing.T) {
 	})
 }
 
+func TestPipeline_WithDependencyRecorder(t *testing.T) {
+	recorder := NewDependencyRecorder[context.Context]()
+	var hookCalls int
+	p := NewPipeline[context.Context]().
+		WithBeforeHooks(func(_ Step[context.Context]) { hookCalls++ }).
+		WithDependencyRecorder(recorder)
+	p.WithSteps(
+		newTestStep("step 1"),
+		p.When(Bool[context.Context](false), "skipped", func(_ context.Context) error { return nil }),
+		newTestStep("step 2"),
+	)
+	err := p.RunWithContext(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, recorder.Records, 2)
+	assert.NoError(t, recorder.RequireDependencyByStepName("step 1", "step 2"))
+	assert.Equal(t, 2, hookCalls, "existing hooks are retained")
+	require.Len(t, recorder.Skipped, 1)
+	assert.Equal(t, "skipped", recorder.Skipped[0].Name)
+	assert.Equal(t, []string{"condition evaluated to false"}, recorder.SkipReasons)
+	assert.Error(t, recorder.RequireDependencyByStepName("skipped"), "skipped steps are not dependencies")
+}
+
+func TestDependencyRecorder_Durations(t *testing.T) {
+	recorder := NewDependencyRecorder[context.Context]()
+	sleep := func(d time.Duration) ActionFunc[context.Context] {
+		return func(_ context.Context) error {
+			time.Sleep(d)
+			return nil
+		}
+	}
+	p := NewPipeline[context.Context]().WithDependencyRecorder(recorder)
+	p.WithSteps(
+		p.NewStep("short", sleep(10*time.Millisecond)),
+		p.WithNestedSteps("nested", nil,
+			p.NewStep("long", sleep(30*time.Millisecond)),
+		),
+	)
+	err := p.RunWithContext(context.Background())
+	require.NoError(t, err)
+	require.Len(t, recorder.Durations, 3)
+	short, nested, long := recorder.Durations[0], recorder.Durations[1], recorder.Durations[2]
+	assert.GreaterOrEqual(t, short, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, long, 30*time.Millisecond)
+	assert.GreaterOrEqual(t, nested, long, "parent includes nested step")
+	assert.Less(t, short, long)
+	assert.Equal(t, short+nested+long, recorder.TotalDuration())
+	assert.NoError(t, recorder.RequireDependencyByStepName("short", "nested", "long"))
+}
+
+func TestDependencyRecorder_RecordResult(t *testing.T) {
+	t.Run("GivenNoRecord_ThenIgnore", func(t *testing.T) {
+		recorder := DependencyRecorder[context.Context]{}
+		assert.NotPanics(t, func() {
+			recorder.RecordResult(newTestStep("test"), nil)
+		})
+		assert.Equal(t, time.Duration(0), recorder.TotalDuration())
+	})
+	t.Run("GivenPresetRecords_ThenAlignDurations", func(t *testing.T) {
+		recorder := DependencyRecorder[context.Context]{Records: []Step[context.Context]{newTestStep("preset")}}
+		recorder.Record(newTestStep("test"))
+		time.Sleep(time.Millisecond)
+		recorder.RecordResult(newTestStep("test"), nil)
+		require.Len(t, recorder.Durations, 2)
+		assert.Equal(t, time.Duration(0), recorder.Durations[0])
+		assert.NotZero(t, recorder.Durations[1])
+	})
+	t.Run("GivenOverlappingSteps_ThenMatchFinishedStep", func(t *testing.T) {
+		recorder := NewDependencyRecorder[context.Context]()
+		slow, fast := newTestStep("slow"), newTestStep("fast")
+		slow.invocation, fast.invocation = 1, 2
+		recorder.Record(slow)
+		recorder.Record(fast)
+		recorder.RecordResult(fast, nil)
+		time.Sleep(10 * time.Millisecond)
+		recorder.RecordResult(slow, nil)
+		require.Len(t, recorder.Durations, 2)
+		assert.GreaterOrEqual(t, recorder.Durations[0], 10*time.Millisecond, "slow")
+		assert.Less(t, recorder.Durations[1], 10*time.Millisecond, "fast")
+	})
+	t.Run("GivenStepsNotRunByPipeline_ThenMatchByName", func(t *testing.T) {
+		recorder := NewDependencyRecorder[context.Context]()
+		recorder.Record(newTestStep("slow"))
+		recorder.Record(newTestStep("fast"))
+		recorder.RecordResult(newTestStep("fast"), nil)
+		time.Sleep(10 * time.Millisecond)
+		recorder.RecordResult(newTestStep("slow"), nil)
+		assert.GreaterOrEqual(t, recorder.Durations[0], 10*time.Millisecond, "slow")
+		assert.Less(t, recorder.Durations[1], 10*time.Millisecond, "fast")
+	})
+}
+
+func TestDependencyRecorder_MarshalJSON(t *testing.T) {
+	recorder := NewDependencyRecorder[context.Context]()
+	recorder.Record(NewStep("first", failingAction))
+	recorder.Record(NewStep("second", sleepUntilDone(0)))
+	data, err := json.Marshal(recorder)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+{"name":"first","func":"github.com/ccremer/go-command-pipeline.failingAction"},
+{"name":"second","func":"github.com/ccremer/go-command-pipeline.sleepUntilDone.func1"}
+]`, string(data))
+
+	data, err = json.Marshal(NewDependencyRecorder[context.Context]())
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(data))
+}
+
+func TestDependencyRecorder_Concurrent(t *testing.T) {
+	recorder := NewDependencyRecorder[context.Context]()
+	sleeps := map[string]time.Duration{"slow": 60 * time.Millisecond, "medium": 30 * time.Millisecond, "fast": 0}
+	pipes := make([]*Pipeline[context.Context], 0, len(sleeps))
+	for name, d := range sleeps {
+		sleep := d
+		pipes = append(pipes, NewPipeline[context.Context]().WithDependencyRecorder(recorder).AddStepFromFunc(name, func(_ context.Context) error {
+			_ = recorder.RequireDependencyByStepName(name)
+			_ = recorder.Snapshot()
+			time.Sleep(sleep)
+			return nil
+		}))
+	}
+	step := NewFanOutStep("fanout", SupplierFromSlice(pipes), AggregateErrors[context.Context]())
+	require.NoError(t, NewPipeline[context.Context]().AddStep(step).RunWithContext(context.Background()))
+
+	require.Len(t, recorder.Records, len(sleeps))
+	require.Len(t, recorder.Durations, len(sleeps))
+	durations := map[string]time.Duration{}
+	for i, record := range recorder.Records {
+		durations[record.Name] = recorder.Durations[i]
+	}
+	assert.GreaterOrEqual(t, durations["slow"], 60*time.Millisecond)
+	assert.GreaterOrEqual(t, durations["medium"], 30*time.Millisecond)
+	assert.Less(t, durations["medium"], 60*time.Millisecond)
+	assert.Less(t, durations["fast"], 30*time.Millisecond)
+}
+
+func TestDependencyRecorder_Reset(t *testing.T) {
+	recorder := NewDependencyRecorder[context.Context]()
+	p := NewPipeline[context.Context]().WithDependencyRecorder(recorder).WithSteps(
+		newTestStep("first"),
+		newTestStep("skipped").When(Bool[context.Context](false)),
+	)
+	require.NoError(t, p.RunWithContext(context.Background()))
+	require.NoError(t, recorder.RequireDependencyByStepName("first"))
+
+	recorder.Reset()
+	assert.Empty(t, recorder.Records)
+	assert.Empty(t, recorder.Durations)
+	assert.Empty(t, recorder.Skipped)
+	assert.Empty(t, recorder.SkipReasons)
+	err := recorder.RequireDependencyByStepName("first")
+	var depErr *DependencyError
+	require.ErrorAs(t, err, &depErr)
+	assert.Equal(t, []string{"first"}, depErr.MissingSteps)
+
+	require.NoError(t, p.RunWithContext(context.Background()))
+	assert.Len(t, recorder.Records, 1, "recorder is reusable")
+	assert.Len(t, recorder.Durations, 1)
+}
+
+func TestDependencyRecorder_Snapshot(t *testing.T) {
+	recorder := NewDependencyRecorder[context.Context]()
+	recorder.Record(newTestStep("first"))
+	snapshot := recorder.Snapshot()
+	recorder.Record(newTestStep("second"))
+	snapshot[0].Name = "modified"
+
+	require.Len(t, snapshot, 1)
+	assert.Equal(t, "first", recorder.Records[0].Name, "snapshot is a copy")
+	assert.Empty(t, NewDependencyRecorder[context.Context]().Snapshot())
+}
+
+func TestFuncName(t *testing.T) {
+	assert.Equal(t, "github.com/ccremer/go-command-pipeline.failingAction", FuncName(failingAction))
+	assert.Equal(t, "github.com/ccremer/go-command-pipeline.sleepUntilDone.func1", FuncName(sleepUntilDone(0)))
+	assert.PanicsWithError(t, "given value is not a function: not a function", func() {
+		FuncName("not a function")
+	})
+}
+
 func newTestStep(name string) Step[context.Context] {
 	return NewStep[context.Context](name, func(_ context.Context) error {
 		fmt.Println(name) // do something with the name to make functions between steps not the same
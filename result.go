@@ -1,24 +1,42 @@
 package pipeline
 
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
 // Result is the object that is returned after each step and after running a pipeline.
 type Result interface {
 	error
 	// Name retrieves the name of the (last) step that has been executed.
 	Name() string
+	// Duration retrieves how long the (last) step ran before it failed.
+	// If the pipeline has been canceled, it is the duration the pipeline ran until the cancellation has been noticed.
+	Duration() time.Duration
+	// IsCanceled returns true if the error is due to a canceled context or an exceeded deadline.
+	IsCanceled() bool
+	// IsAborted returns true if the error is ErrAbort, see ErrAbort.
+	IsAborted() bool
 }
 
 type resultImpl struct {
-	err  error
-	name string
+	err      error
+	name     string
+	duration time.Duration
+	// skipRemaining is true if the step returned ErrSkipRemaining itself, see skipsRemaining.
+	skipRemaining bool
 }
 
-func newResult(stepName string, err error) Result {
+func newResult(stepName string, err error, duration time.Duration) Result {
 	if err == nil {
 		panic("error cannot be nil: " + stepName)
 	}
 	return resultImpl{
-		name: stepName,
-		err:  err,
+		name:     stepName,
+		err:      err,
+		duration: duration,
 	}
 }
 
@@ -30,7 +48,81 @@ func (r resultImpl) Name() string {
 	return r.name
 }
 
+func (r resultImpl) Duration() time.Duration {
+	return r.duration
+}
+
+func (r resultImpl) IsCanceled() bool {
+	return errors.Is(r.err, context.Canceled) || errors.Is(r.err, context.DeadlineExceeded)
+}
+
+func (r resultImpl) IsAborted() bool {
+	return errors.Is(r.err, ErrAbort)
+}
+
 // Unwrap implements xerrors.Wrapper.
 func (r resultImpl) Unwrap() error {
 	return r.err
 }
+
+// MultiError is an error that aggregates the errors of multiple steps or pipelines.
+// Each aggregated error is a Result, so that the name of the failed step is retained for reporting.
+// errors.Is and errors.As inspect each of the aggregated errors.
+type MultiError struct {
+	// Results contains the aggregated errors in the order they have been appended.
+	Results []Result
+}
+
+// Append adds the given error to the aggregate, unless it is nil.
+// If err is not a Result, it is wrapped in a Result with the name and duration of the step that failed, or with the given step name as a fallback.
+func (e *MultiError) Append(stepName string, err error) {
+	if err == nil {
+		return
+	}
+	if result, ok := err.(Result); ok {
+		e.Results = append(e.Results, result)
+		return
+	}
+	var duration time.Duration
+	var nested Result
+	if errors.As(err, &nested) {
+		stepName = nested.Name()
+		duration = nested.Duration()
+	}
+	e.Results = append(e.Results, newResult(stepName, err, duration))
+}
+
+// ErrorOrNil returns the MultiError itself if it contains at least one error, otherwise nil.
+func (e *MultiError) ErrorOrNil() error {
+	if e == nil || len(e.Results) == 0 {
+		return nil
+	}
+	return e
+}
+
+// FailedStepNames returns the names of the failed steps in the order they have been appended.
+func (e *MultiError) FailedStepNames() []string {
+	names := make([]string, len(e.Results))
+	for i, result := range e.Results {
+		names[i] = result.Name()
+	}
+	return names
+}
+
+// Error returns the messages of the aggregated errors separated by newlines.
+func (e *MultiError) Error() string {
+	messages := make([]string, len(e.Results))
+	for i, result := range e.Results {
+		messages[i] = result.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the aggregated errors.
+func (e *MultiError) Unwrap() []error {
+	errs := make([]error, len(e.Results))
+	for i, result := range e.Results {
+		errs[i] = result
+	}
+	return errs
+}
@@ -0,0 +1,91 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiError_FailedStepNames(t *testing.T) {
+	errSentinel := errors.New("sentinel")
+	pipes := []*Pipeline[context.Context]{
+		NewPipeline[context.Context]().AddStepFromFunc("first", func(_ context.Context) error {
+			return errors.New("first failed")
+		}),
+		NewPipeline[context.Context]().AddStepFromFunc("succeeding", func(_ context.Context) error {
+			return nil
+		}),
+		NewPipeline[context.Context]().AddStepFromFunc("third", func(_ context.Context) error {
+			return errSentinel
+		}),
+	}
+	agg := &MultiError{}
+	for _, p := range pipes {
+		agg.Append("unknown", p.RunWithContext(context.Background()))
+	}
+	agg.Append("plain", errors.New("plain error"))
+	agg.Append("wrapped", fmt.Errorf("wrapped: %w", newResult("nested", errors.New("nested error"), time.Second)))
+
+	err := agg.ErrorOrNil()
+	require.Error(t, err)
+	assert.Equal(t, []string{"first", "third", "plain", "nested"}, agg.FailedStepNames())
+	assert.Equal(t, time.Second, agg.Results[3].Duration(), "duration of nested result")
+	assert.EqualError(t, err, "step 'first' failed: first failed\nstep 'third' failed: sentinel\nplain error\nwrapped: nested error")
+	assert.ErrorIs(t, err, errSentinel)
+	var result Result
+	require.True(t, errors.As(err, &result))
+	assert.Equal(t, "first", result.Name())
+}
+
+func TestMultiError_ErrorOrNil(t *testing.T) {
+	agg := &MultiError{}
+	agg.Append("step", nil)
+	assert.NoError(t, agg.ErrorOrNil())
+	assert.Empty(t, agg.FailedStepNames())
+}
+
+func TestResult_Classification(t *testing.T) {
+	tests := map[string]struct {
+		givenErr         error
+		expectedCanceled bool
+		expectedAborted  bool
+	}{
+		"GivenPlainError_ThenNeitherCanceledNorAborted": {
+			givenErr: errors.New("failed"),
+		},
+		"GivenCanceled_ThenCanceled": {
+			givenErr:         fmt.Errorf("step failed: %w", context.Canceled),
+			expectedCanceled: true,
+		},
+		"GivenDeadlineExceeded_ThenCanceled": {
+			givenErr:         context.DeadlineExceeded,
+			expectedCanceled: true,
+		},
+		"GivenErrAbort_ThenAborted": {
+			givenErr:        fmt.Errorf("step failed: %w", ErrAbort),
+			expectedAborted: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := newResult("step", tc.givenErr, 0)
+			assert.Equal(t, tc.expectedCanceled, result.IsCanceled())
+			assert.Equal(t, tc.expectedAborted, result.IsAborted())
+		})
+	}
+}
+
+func TestResult_IsCanceled_Pipeline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := NewPipeline[context.Context]().AddStepFromFunc("step", failingAction).RunWithContext(ctx)
+	var result Result
+	require.ErrorAs(t, err, &result)
+	assert.True(t, result.IsCanceled())
+	assert.False(t, result.IsAborted())
+}
@@ -2,25 +2,167 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 )
 
 // ParallelResultHandler is a callback that provides a Result map and expect a single, combined Result object.
 // The map key is a zero-based index of n-th Pipeline spawned, e.g. pipeline number 3 will have index 2.
+// The index corresponds to the order in which the Supplier put the pipelines into the channel, regardless of the order in which they have been run.
 // Return an empty error if you want to ignore errors, or reduce multiple errors into a single one to make the parent Pipeline fail.
 type ParallelResultHandler[T context.Context] func(ctx T, results map[uint64]error) error
 
+// ParallelResult is the outcome of a child pipeline run by a parallel step like NewFanOutStepWithDurations.
+type ParallelResult struct {
+	// Index is the zero-based index in which the pipeline has been supplied, see ParallelResultHandler.
+	Index uint64
+	// Err is the error returned by the pipeline, or nil if it was successful.
+	Err error
+	// Duration is the time the pipeline took to run.
+	// It is zero if the pipeline has been abandoned, see ParallelOptions.GracePeriod.
+	Duration time.Duration
+}
+
+// ParallelDurationHandler is similar to ParallelResultHandler, but it receives the result of each child pipeline as ParallelResult, e.g. to identify the slowest child pipeline.
+type ParallelDurationHandler[T context.Context] func(ctx T, results map[uint64]ParallelResult) error
+
+// ParallelResultMapHandler is similar to ParallelResultHandler, but it receives the error of each child pipeline as Result, e.g. to inspect Result.Name or Result.IsCanceled.
+// The map contains nil for each child pipeline that was successful.
+// If a child pipeline returned an error that is not a Result, e.g. because a finalizer replaced it, the error is wrapped in a Result that has an empty name.
+type ParallelResultMapHandler[T context.Context] func(ctx T, results map[uint64]Result) error
+
+// handleResults returns a ParallelResultHandler that converts the errors to Result and passes them to the given handler.
+func handleResults[T context.Context](handler ParallelResultMapHandler[T]) ParallelResultHandler[T] {
+	if handler == nil {
+		return nil
+	}
+	return func(ctx T, errs map[uint64]error) error {
+		results := make(map[uint64]Result, len(errs))
+		for key, err := range errs {
+			results[key] = asResult(err)
+		}
+		return handler(ctx, results)
+	}
+}
+
+// asResult returns err as Result, or nil if err is nil.
+func asResult(err error) Result {
+	if err == nil {
+		return nil
+	}
+	if result, isResult := err.(Result); isResult {
+		return result
+	}
+	return newResult("", err, 0)
+}
+
+// AggregateErrors returns a ParallelResultHandler that combines all non-nil errors of the child pipelines into a single error using errors.Join.
+// The errors are joined in the order of the map keys, and each of them remains accessible with errors.Is and errors.As, e.g. to retrieve the Result of each failed child pipeline.
+// It returns nil if all child pipelines were successful.
+// This is the recommended handler unless the child errors need special treatment.
+func AggregateErrors[T context.Context]() ParallelResultHandler[T] {
+	return func(_ T, results map[uint64]error) error {
+		errs := make([]error, 0, len(results))
+		for _, key := range SortedResults(results) {
+			errs = append(errs, results[key])
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// SortedResultHandler returns a ParallelResultHandler that invokes fn for each child pipeline in ascending order of the index, regardless of the order in which the pipelines completed.
+// This makes reporting deterministic, e.g. to print the results in tests or Example functions.
+// fn is also invoked for successful child pipelines, in which case err is nil.
+// The errors returned by fn are combined with errors.Join.
+func SortedResultHandler[T context.Context](fn func(ctx T, index uint64, err error) error) ParallelResultHandler[T] {
+	return func(ctx T, results map[uint64]error) error {
+		var errs []error
+		for _, key := range SortedResults(results) {
+			if err := fn(ctx, key, results[key]); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// SortedResults returns the keys of the given results in ascending order, e.g. to iterate over the results of a ParallelResultHandler deterministically.
+func SortedResults(results map[uint64]error) []uint64 {
+	keys := make([]uint64, 0, len(results))
+	for key := range results {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// CountErrors returns the number of non-nil errors in the given results, e.g. to count the failed child pipelines in a ParallelResultHandler.
+func CountErrors(results map[uint64]error) int {
+	count := 0
+	for _, err := range results {
+		if err != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// FirstError returns the non-nil error with the lowest index in the given results, or nil if there is none.
+// The index corresponds to the order in which the pipelines have been supplied, not the order in which they failed.
+func FirstError(results map[uint64]error) error {
+	var first error
+	firstKey := uint64(0)
+	for key, err := range results {
+		if err != nil && (first == nil || key < firstKey) {
+			first = err
+			firstKey = key
+		}
+	}
+	return first
+}
+
+// AllSucceeded returns true if all errors in the given results are nil.
+// It returns true for empty results.
+func AllSucceeded(results map[uint64]error) bool {
+	return CountErrors(results) == 0
+}
+
+// resultCollector passes the ParallelResult values stored in the given map by a parallel step to a handler.
+type resultCollector[T context.Context] func(ctx T, m *sync.Map) error
+
+// collectErrors returns a resultCollector for the given ParallelResultHandler.
+func collectErrors[T context.Context](handler ParallelResultHandler[T]) resultCollector[T] {
+	return func(ctx T, m *sync.Map) error {
+		return collectResults(ctx, handler, m)
+	}
+}
+
+// collectDurations returns a resultCollector for the given ParallelDurationHandler.
+func collectDurations[T context.Context](handler ParallelDurationHandler[T]) resultCollector[T] {
+	return func(ctx T, m *sync.Map) error {
+		if handler == nil {
+			return nil
+		}
+		resultMap := make(map[uint64]ParallelResult)
+		m.Range(func(key, value interface{}) bool {
+			resultMap[key.(uint64)] = value.(ParallelResult)
+			return true
+		})
+		return handler(ctx, resultMap)
+	}
+}
+
 func collectResults[T context.Context](ctx T, handler ParallelResultHandler[T], m *sync.Map) error {
 	if handler != nil {
 		// convert sync.Map to conventional map for easier access
 		resultMap := make(map[uint64]error)
 		m.Range(func(key, value interface{}) bool {
-			if value == nil {
-				resultMap[key.(uint64)] = nil
-			} else {
-				resultMap[key.(uint64)] = value.(error)
-			}
+			resultMap[key.(uint64)] = value.(ParallelResult).Err
 			return true
 		})
 		return handler(ctx, resultMap)
@@ -31,10 +173,10 @@ func collectResults[T context.Context](ctx T, handler ParallelResultHandler[T],
 func setResultErrorFromContext(ctx context.Context, name string, err error) error {
 	if ctx.Err() != nil {
 		if err != nil {
-			wrapped := fmt.Errorf("%w, collection error: %v", ctx.Err(), err)
-			return newResult(name, wrapped)
+			wrapped := fmt.Errorf("%w, collection error: %v", contextError(ctx), err)
+			return newResult(name, wrapped, 0)
 		}
-		return newResult(name, ctx.Err())
+		return newResult(name, contextError(ctx), 0)
 	}
 	return err
 }
@@ -0,0 +1,158 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateErrors(t *testing.T) {
+	tests := map[string]struct {
+		givenResults  map[uint64]error
+		expectedError string
+		expectedNames []string
+	}{
+		"GivenNoResults_ThenReturnNil": {
+			givenResults: map[uint64]error{},
+		},
+		"GivenOnlySuccessfulResults_ThenReturnNil": {
+			givenResults: map[uint64]error{0: nil, 1: nil},
+		},
+		"GivenFailedResults_ThenJoinInOrderOfIndex": {
+			givenResults: map[uint64]error{
+				3: newResult("fourth", errors.New("fourth failed"), 0),
+				0: newResult("first", errors.New("first failed"), 0),
+				1: nil,
+				2: newResult("third", errors.New("third failed"), 0),
+			},
+			expectedError: "first failed\nthird failed\nfourth failed",
+			expectedNames: []string{"first", "third", "fourth"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := AggregateErrors[context.Context]()(context.Background(), tc.givenResults)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.expectedError)
+			joined, ok := err.(interface{ Unwrap() []error })
+			require.True(t, ok, "joined error")
+			var names []string
+			for _, child := range joined.Unwrap() {
+				var result Result
+				require.True(t, errors.As(child, &result))
+				names = append(names, result.Name())
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func ExampleAggregateErrors() {
+	p := NewPipeline[context.Context]()
+	p.AddStep(NewFanOutStep[context.Context]("fanout", SupplierFromSlice([]*Pipeline[context.Context]{
+		NewPipeline[context.Context]().AddStepFromFunc("first", func(_ context.Context) error {
+			return errors.New("first failed")
+		}),
+		NewPipeline[context.Context]().AddStepFromFunc("second", func(_ context.Context) error {
+			return nil
+		}),
+		NewPipeline[context.Context]().AddStepFromFunc("third", func(_ context.Context) error {
+			return errors.New("third failed")
+		}),
+	}), AggregateErrors[context.Context]()))
+	err := p.RunWithContext(context.Background())
+	fmt.Println(err)
+	// Output: step 'fanout' failed: step 'first' failed: first failed
+	// step 'third' failed: third failed
+}
+
+func TestResultReducers(t *testing.T) {
+	first := errors.New("first")
+	second := errors.New("second")
+	tests := map[string]struct {
+		givenResults         map[uint64]error
+		expectedCount        int
+		expectedFirst        error
+		expectedAllSucceeded bool
+	}{
+		"GivenNilMap_ThenReturnSuccess": {
+			givenResults:         nil,
+			expectedAllSucceeded: true,
+		},
+		"GivenOnlySuccess_ThenReturnSuccess": {
+			givenResults:         map[uint64]error{0: nil, 1: nil},
+			expectedAllSucceeded: true,
+		},
+		"GivenMixedResults_ThenReturnErrorWithLowestIndex": {
+			givenResults:  map[uint64]error{0: nil, 1: first, 2: nil, 3: second},
+			expectedCount: 2,
+			expectedFirst: first,
+		},
+		"GivenOnlyErrors_ThenCountAll": {
+			givenResults:  map[uint64]error{5: second, 2: first},
+			expectedCount: 2,
+			expectedFirst: first,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedCount, CountErrors(tc.givenResults))
+			assert.Equal(t, tc.expectedFirst, FirstError(tc.givenResults))
+			assert.Equal(t, tc.expectedAllSucceeded, AllSucceeded(tc.givenResults))
+		})
+	}
+}
+
+func TestSortedResults(t *testing.T) {
+	results := map[uint64]error{}
+	for i := uint64(0); i < 20; i++ {
+		results[19-i] = nil
+	}
+	keys := SortedResults(results)
+	require.Len(t, keys, 20)
+	for i, key := range keys {
+		assert.Equal(t, uint64(i), key)
+	}
+	assert.Empty(t, SortedResults(nil))
+}
+
+func TestSortedResultHandler(t *testing.T) {
+	var indices []uint64
+	handler := SortedResultHandler(func(_ context.Context, index uint64, err error) error {
+		indices = append(indices, index)
+		if err != nil {
+			return fmt.Errorf("child %d: %w", index, err)
+		}
+		return nil
+	})
+	err := handler(context.Background(), map[uint64]error{3: errors.New("failed"), 0: nil, 2: nil, 1: errors.New("failed")})
+	assert.EqualError(t, err, "child 1: failed\nchild 3: failed")
+	assert.Equal(t, []uint64{0, 1, 2, 3}, indices)
+}
+
+func ExampleSortedResultHandler() {
+	pipes := make([]*Pipeline[context.Context], 3)
+	for i := range pipes {
+		pipes[i] = NewPipeline[context.Context]().AddStepFromFunc(fmt.Sprintf("job %d", i), func(_ context.Context) error {
+			return nil
+		})
+	}
+	fanout := NewFanOutStep("fanout", SupplierFromSlice(pipes), SortedResultHandler(func(_ context.Context, index uint64, err error) error {
+		fmt.Printf("pipeline %d: %v\n", index, err)
+		return nil
+	}))
+	err := NewPipeline[context.Context]().AddStep(fanout).RunWithContext(context.Background())
+	if err != nil {
+		fmt.Println(err)
+	}
+	// Output: pipeline 0: <nil>
+	// pipeline 1: <nil>
+	// pipeline 2: <nil>
+}
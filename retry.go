@@ -0,0 +1,77 @@
+package pipeline
+
+import (
+	"math"
+	"time"
+)
+
+// BackoffFunc returns the duration to wait before the next attempt.
+// The given attempt is the 1-based number of the attempt that just failed.
+type BackoffFunc func(attempt int) time.Duration
+
+// WithRetry wraps Step.Action so that it is invoked up to the given number of attempts as long as it returns an error.
+// Between the attempts, it waits for the duration returned by the given BackoffFunc, or not at all if it is nil.
+// A success on any attempt clears the error of the previous attempts.
+// The error of the last attempt is returned once all attempts are exhausted.
+// If the context is canceled, no more attempts are made and the context's error is returned immediately.
+// It panics if attempts is lower than 1.
+func (s Step[T]) WithRetry(attempts int, backoff BackoffFunc) Step[T] {
+	if attempts < 1 {
+		panic("retry attempts cannot be lower than 1")
+	}
+	action := s.Action
+	s.Action = func(ctx T) error {
+		var err error
+		for attempt := 1; attempt <= attempts; attempt++ {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			err = action(ctx)
+			if err == nil || attempt == attempts {
+				return err
+			}
+			var wait time.Duration
+			if backoff != nil {
+				wait = backoff(attempt)
+			}
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+		return err
+	}
+	return s
+}
+
+// ConstantBackoff returns a BackoffFunc that always waits for the given duration.
+func ConstantBackoff(d time.Duration) BackoffFunc {
+	return func(_ int) time.Duration {
+		return d
+	}
+}
+
+// LinearBackoff returns a BackoffFunc that waits for the given duration multiplied by the attempt number, e.g. 1s, 2s, 3s.
+func LinearBackoff(d time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		return d * time.Duration(attempt)
+	}
+}
+
+// ExponentialBackoff returns a BackoffFunc that waits for the given duration doubled with every attempt, e.g. 1s, 2s, 4s.
+// Instead of overflowing, the wait saturates at the largest possible time.Duration.
+func ExponentialBackoff(d time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		shift := attempt - 1
+		if d <= 0 || shift <= 0 {
+			return d
+		}
+		if shift >= 63 || d > math.MaxInt64>>shift {
+			return math.MaxInt64
+		}
+		return d << shift
+	}
+}
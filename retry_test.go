@@ -0,0 +1,114 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStep_WithRetry(t *testing.T) {
+	tests := map[string]struct {
+		givenAttempts     int
+		givenSuccessAfter int
+		expectedCalls     int
+		expectedError     string
+	}{
+		"GivenSuccessOnFirstAttempt_ThenDontRetry": {
+			givenAttempts:     3,
+			givenSuccessAfter: 1,
+			expectedCalls:     1,
+		},
+		"GivenSuccessOnLastAttempt_ThenClearError": {
+			givenAttempts:     3,
+			givenSuccessAfter: 3,
+			expectedCalls:     3,
+		},
+		"GivenPermanentFailure_ThenReturnLastError": {
+			givenAttempts:     3,
+			givenSuccessAfter: 10,
+			expectedCalls:     3,
+			expectedError:     "step 'flaky' failed: attempt 3 failed",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			p := NewPipeline[context.Context]()
+			p.AddStep(p.NewStep("flaky", func(_ context.Context) error {
+				calls++
+				if calls >= tc.givenSuccessAfter {
+					return nil
+				}
+				return fmt.Errorf("attempt %d failed", calls)
+			}).WithRetry(tc.givenAttempts, ConstantBackoff(time.Millisecond)))
+			err := p.RunWithContext(context.Background())
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
+
+func TestStep_WithRetry_Cancel(t *testing.T) {
+	calls := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	step := NewStep("flaky", func(_ context.Context) error {
+		calls++
+		cancel()
+		return errors.New("failed")
+	}).WithRetry(3, ConstantBackoff(time.Second))
+	start := time.Now()
+	err := step.Action(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestStep_WithRetry_InvalidAttempts(t *testing.T) {
+	assert.PanicsWithValue(t, "retry attempts cannot be lower than 1", func() {
+		NewStep("flaky", failingAction).WithRetry(0, nil)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	tests := map[string]struct {
+		givenBackoff BackoffFunc
+		expected     []time.Duration
+	}{
+		"ConstantBackoff": {
+			givenBackoff: ConstantBackoff(time.Second),
+			expected:     []time.Duration{time.Second, time.Second, time.Second},
+		},
+		"LinearBackoff": {
+			givenBackoff: LinearBackoff(time.Second),
+			expected:     []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+		},
+		"ExponentialBackoff": {
+			givenBackoff: ExponentialBackoff(time.Second),
+			expected:     []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			for i, expected := range tc.expected {
+				assert.Equal(t, expected, tc.givenBackoff(i+1), "attempt %d", i+1)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoff_Overflow(t *testing.T) {
+	backoff := ExponentialBackoff(time.Second)
+	assert.Equal(t, time.Second<<33, backoff(34), "largest attempt without overflow")
+	for _, attempt := range []int{35, 63, 64, 1000} {
+		assert.Equal(t, time.Duration(math.MaxInt64), backoff(attempt), "attempt %d saturates", attempt)
+	}
+}
@@ -0,0 +1,33 @@
+package slogmw
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+)
+
+// LoggingMiddleware returns a middleware that logs structured messages around each step using the given logger.
+// Add it with pipeline.Pipeline.WithStepAwareMiddleware.
+//
+// Just before the step's action runs, "step started" is logged with slog.LevelDebug.
+// After the action has returned, "step finished" is logged with slog.LevelInfo, or with slog.LevelError if the step failed.
+// The messages have the attributes "step" with the step's name, and "duration" and "error" (if any) after the step finished.
+// The messages are logged with the context given to the action, so that handlers can extract values from it.
+func LoggingMiddleware[T context.Context](logger *slog.Logger) pipeline.StepAwareMiddleware[T] {
+	return func(step pipeline.Step[T], next pipeline.ActionFunc[T]) pipeline.ActionFunc[T] {
+		return func(ctx T) error {
+			logger.DebugContext(ctx, "step started", slog.String("step", step.Name))
+			start := time.Now()
+			err := next(ctx)
+			attrs := []any{slog.String("step", step.Name), slog.Duration("duration", time.Since(start))}
+			if err != nil {
+				logger.ErrorContext(ctx, "step finished", append(attrs, slog.Any("error", err))...)
+				return err
+			}
+			logger.InfoContext(ctx, "step finished", attrs...)
+			return nil
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package slogmw
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHandler is a slog.Handler that captures all records.
+type recordingHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordingHandler) Enabled(_ context.Context, _ slog.Level) bool {
+	return true
+}
+
+func (h *recordingHandler) Handle(_ context.Context, record slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, record)
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs(_ []slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *recordingHandler) WithGroup(_ string) slog.Handler {
+	return h
+}
+
+func attributes(record slog.Record) map[string]slog.Value {
+	attrs := map[string]slog.Value{}
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs[attr.Key] = attr.Value
+		return true
+	})
+	return attrs
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	handler := &recordingHandler{}
+	p := pipeline.NewPipeline[context.Context]().WithStepAwareMiddleware(LoggingMiddleware[context.Context](slog.New(handler)))
+	p.WithSteps(
+		p.NewStep("first", func(_ context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}),
+		p.NewStep("failing", func(_ context.Context) error {
+			return errors.New("failed")
+		}),
+	)
+	err := p.RunWithContext(context.Background())
+	require.Error(t, err)
+
+	require.Len(t, handler.records, 4)
+	expected := []struct {
+		level   slog.Level
+		message string
+		step    string
+	}{
+		{slog.LevelDebug, "step started", "first"},
+		{slog.LevelInfo, "step finished", "first"},
+		{slog.LevelDebug, "step started", "failing"},
+		{slog.LevelError, "step finished", "failing"},
+	}
+	for i, record := range handler.records {
+		assert.Equal(t, expected[i].level, record.Level, "level of record %d", i)
+		assert.Equal(t, expected[i].message, record.Message, "message of record %d", i)
+		attrs := attributes(record)
+		assert.Equal(t, expected[i].step, attrs["step"].String(), "step of record %d", i)
+	}
+	finished := attributes(handler.records[1])
+	assert.GreaterOrEqual(t, finished["duration"].Duration(), 10*time.Millisecond)
+	assert.NotContains(t, finished, "error")
+	failed := attributes(handler.records[3])
+	assert.Contains(t, failed, "duration")
+	assert.EqualError(t, failed["error"].Any().(error), "failed")
+}
+
+func TestLoggingMiddleware_Concurrent(t *testing.T) {
+	handler := &recordingHandler{}
+	logging := LoggingMiddleware[context.Context](slog.New(handler))
+	slow := pipeline.NewPipeline[context.Context]().WithStepAwareMiddleware(logging)
+	slow.AddStepFromFunc("slow", func(_ context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+	fast := pipeline.NewPipeline[context.Context]().WithStepAwareMiddleware(logging)
+	fast.AddStepFromFunc("fast", func(_ context.Context) error {
+		return nil
+	})
+	p := pipeline.NewPipeline[context.Context]()
+	p.WithSteps(pipeline.NewFanOutStep[context.Context]("fan-out", pipeline.SupplierFromSlice([]*pipeline.Pipeline[context.Context]{slow, fast}), nil))
+	err := p.RunWithContext(context.Background())
+	require.NoError(t, err)
+
+	durations := map[string]time.Duration{}
+	for _, record := range handler.records {
+		if record.Message == "step finished" {
+			attrs := attributes(record)
+			durations[attrs["step"].String()] = attrs["duration"].Duration()
+		}
+	}
+	require.Len(t, durations, 2)
+	assert.GreaterOrEqual(t, durations["slow"], 50*time.Millisecond)
+	assert.Less(t, durations["fast"], 50*time.Millisecond)
+}
@@ -3,6 +3,8 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 )
 
 // Step is an intermediary action and part of a Pipeline.
@@ -19,9 +21,46 @@ type Step[T context.Context] struct {
 	// The function may return nil even if the given error is non-nil, in which case the pipeline will continue.
 	// This function is called before the next step's Action is invoked.
 	Handler ErrorHandler[T]
+	// SuccessHandler is an optional callback that is called if the Action and the Handler (if any) returned no error.
+	// It is not called if the step is skipped, fails or doesn't run due to a canceled context.
+	// See Step.OnSuccess.
+	SuccessHandler func(ctx T)
 	// Condition determines if the Step's Action is actually going to be executed in the pipeline.
 	// When nil, the Action is executed.
 	Condition Predicate[T]
+	// ConditionDescription is an optional description of the Condition.
+	// It is reported as the reason to SkippedListener when the step is skipped, see Step.WhenDescribed.
+	ConditionDescription string
+	// Timeout bounds the execution time of the Action, independently of the parent context's deadline.
+	// The Action is given a derived context that is canceled after the timeout, the parent context remains untouched.
+	// If zero, Options.DefaultStepTimeout applies.
+	// A negative timeout disables Options.DefaultStepTimeout for this step.
+	// If T is not context.Context, it has to implement ContextDeriver, otherwise running the step panics.
+	Timeout time.Duration
+	// ActionID is an optional, explicit identity of the Action.
+	// Unlike function names, it can be used to reliably identify generated functions and closures, e.g. with DependencyResolver.RequireDependencyByActionID.
+	ActionID string
+	// BeforeHooks are listeners that are called only for this step, after the listeners of Pipeline.WithBeforeHooks and just before the Action is invoked.
+	// See Step.WithBeforeHook.
+	BeforeHooks []Listener[T]
+	// Dependencies are the names of the steps that have to run before this step when running the pipeline with Pipeline.RunTopological.
+	// See Step.DependsOn.
+	Dependencies []string
+	// Weight is the relative amount of work of the step that is used by ProgressReporter to estimate the progress of a pipeline run.
+	// If zero, the weight is estimated from the durations of a prior run, or the step weighs the same as any other step.
+	// See Step.WithWeight.
+	Weight float64
+
+	// nestedSteps returns the steps of the nested pipeline if the step has been created with Pipeline.AsNestedStep or Pipeline.WithNestedSteps.
+	// The Action is opaque, hence this metadata allows tools like Pipeline.ToDOT to descend into nested pipelines.
+	nestedSteps func() []Step[T]
+	// deferred marks the step to always run after the other steps of the pipeline, see Defer.
+	deferred bool
+	// invocation identifies a single run of the step by a Pipeline, so that listeners can correlate the before and after hooks of the same run.
+	// It is zero if the step isn't running.
+	invocation uint64
+	// jumpTarget is the name of the step that the pipeline continues with if the step has been created with Jump.
+	jumpTarget string
 }
 
 // NewStep returns a new Step with given name and action.
@@ -40,15 +79,136 @@ func NewStepIf[T context.Context](predicate Predicate[T], name string, actionFun
 	return NewStep[T](name, actionFunc).When(predicate)
 }
 
+// WithCleanup returns a copy of the main step whose Action always runs the given cleanup func after the main Action, like try/finally.
+// The cleanup runs even if the main Action returned an error or panicked, in which case the panic continues after the cleanup.
+// The error of the main Action is returned, or the error of the cleanup if the main Action succeeded.
+// Step.Handler and Step.Condition of the main step remain effective.
+func WithCleanup[T context.Context](main Step[T], cleanup ActionFunc[T]) Step[T] {
+	action := main.Action
+	main.Action = func(ctx T) (err error) {
+		defer func() {
+			cleanupErr := cleanup(ctx)
+			if err == nil {
+				err = cleanupErr
+			}
+		}()
+		return action(ctx)
+	}
+	return main
+}
+
+// Defer returns a copy of the given step that always runs after the other steps of the pipeline, like a Go defer statement.
+// It runs even if a previous step failed or the pipeline has been canceled, e.g. to clean up resources within a nested pipeline.
+// Note that the context may already be canceled when the deferred step runs.
+// Unlike Go defers, the position of the step in the pipeline doesn't matter: all deferred steps of a pipeline run once the other steps are done, even if the pipeline has been aborted before reaching them.
+// Multiple deferred steps run in reverse order (LIFO) of their position in the pipeline.
+// The errors of failed deferred steps are aggregated with the pipeline's error in a MultiError.
+// Step.Condition of the deferred step remains effective.
+func Defer[T context.Context](step Step[T]) Step[T] {
+	step.deferred = true
+	return step
+}
+
+// WithHeartbeat returns a copy of the step whose Action invokes fn periodically with the given interval while the Action runs, e.g. to update a spinner.
+// fn receives the time elapsed since the Action started.
+// It is called from another Go routine, but never concurrently with itself.
+// The heartbeat stops before the step completes, even if the Action panicked.
+// It panics if the interval is not positive.
+func (s Step[T]) WithHeartbeat(interval time.Duration, fn func(ctx T, elapsed time.Duration)) Step[T] {
+	if interval <= 0 {
+		panic(fmt.Errorf("heartbeat interval must be positive for step %q", s.Name))
+	}
+	action := s.Action
+	s.Action = func(ctx T) error {
+		start := time.Now()
+		ticker := time.NewTicker(interval)
+		done := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				case tick := <-ticker.C:
+					fn(ctx, tick.Sub(start))
+				}
+			}
+		}()
+		defer func() {
+			ticker.Stop()
+			close(done)
+			wg.Wait()
+		}()
+		return action(ctx)
+	}
+	return s
+}
+
 // WithErrorHandler sets the ErrorHandler of this specific step and returns the step itself.
 func (s Step[T]) WithErrorHandler(errorHandler ErrorHandler[T]) Step[T] {
 	s.Handler = errorHandler
 	return s
 }
 
-// When sets Step.Condition.
+// OnSuccess sets Step.SuccessHandler and returns the step itself.
+// It is the counterpart to WithErrorHandler, e.g. to emit success events or update progress.
+func (s Step[T]) OnSuccess(fn func(ctx T)) Step[T] {
+	s.SuccessHandler = fn
+	return s
+}
+
+// When sets Step.Condition and clears any Step.ConditionDescription.
 // When the given predicate returns false, the step is skipped without error.
 func (s Step[T]) When(predicate Predicate[T]) Step[T] {
 	s.Condition = predicate
+	s.ConditionDescription = ""
+	return s
+}
+
+// WhenDescribed is When, but it also sets Step.ConditionDescription from the given predicate.
+func (s Step[T]) WhenDescribed(predicate DescribedPredicate[T]) Step[T] {
+	s.Condition = predicate.Predicate
+	s.ConditionDescription = predicate.Describe()
+	return s
+}
+
+// skipReason returns the reason why the step is skipped when its Condition evaluates to false.
+func (s Step[T]) skipReason() string {
+	if s.ConditionDescription != "" {
+		return s.ConditionDescription
+	}
+	return "condition evaluated to false"
+}
+
+// WithTimeout sets Step.Timeout and returns the step itself.
+func (s Step[T]) WithTimeout(timeout time.Duration) Step[T] {
+	s.Timeout = timeout
+	return s
+}
+
+// WithActionID sets Step.ActionID and returns the step itself.
+func (s Step[T]) WithActionID(id string) Step[T] {
+	s.ActionID = id
+	return s
+}
+
+// DependsOn appends the given step names to Step.Dependencies and returns the step itself.
+func (s Step[T]) DependsOn(names ...string) Step[T] {
+	s.Dependencies = append(s.Dependencies[:len(s.Dependencies):len(s.Dependencies)], names...)
+	return s
+}
+
+// WithWeight sets Step.Weight and returns the step itself.
+func (s Step[T]) WithWeight(w float64) Step[T] {
+	s.Weight = w
+	return s
+}
+
+// WithBeforeHook appends the given listener to Step.BeforeHooks and returns the step itself.
+// The listener is only called for this step, after the global before hooks.
+func (s Step[T]) WithBeforeHook(listener Listener[T]) Step[T] {
+	s.BeforeHooks = append(s.BeforeHooks[:len(s.BeforeHooks):len(s.BeforeHooks)], listener)
 	return s
 }
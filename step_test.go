@@ -0,0 +1,333 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestWithCleanup(t *testing.T) {
+	tests := map[string]struct {
+		givenAction       ActionFunc[context.Context]
+		givenCleanupError error
+		expectedError     string
+		expectPanic       bool
+	}{
+		"GivenMainSuccess_ThenRunCleanup": {
+			givenAction: func(_ context.Context) error {
+				return nil
+			},
+		},
+		"GivenMainSuccess_WhenCleanupFails_ThenReturnCleanupError": {
+			givenAction: func(_ context.Context) error {
+				return nil
+			},
+			givenCleanupError: errors.New("cleanup failed"),
+			expectedError:     "cleanup failed",
+		},
+		"GivenMainFailure_ThenRunCleanupAndReturnMainError": {
+			givenAction: func(_ context.Context) error {
+				return errors.New("main failed")
+			},
+			givenCleanupError: errors.New("cleanup failed"),
+			expectedError:     "main failed",
+		},
+		"GivenMainPanic_ThenRunCleanupAndPanic": {
+			givenAction: func(_ context.Context) error {
+				panic("main panicked")
+			},
+			expectPanic: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cleanupCalls := 0
+			step := WithCleanup(NewStep("main", tc.givenAction), func(_ context.Context) error {
+				cleanupCalls++
+				return tc.givenCleanupError
+			})
+			assert.Equal(t, "main", step.Name)
+			if tc.expectPanic {
+				assert.PanicsWithValue(t, "main panicked", func() {
+					_ = step.Action(context.Background())
+				})
+				assert.Equal(t, 1, cleanupCalls)
+				return
+			}
+			err := step.Action(context.Background())
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, 1, cleanupCalls)
+		})
+	}
+}
+
+func TestDefer(t *testing.T) {
+	tests := map[string]struct {
+		givenFailingStep    bool
+		givenFailingCleanup bool
+		givenCanceled       bool
+		expectedCalls       []string
+		expectedError       string
+	}{
+		"GivenSuccessfulSteps_ThenRunDeferredStepsLast": {
+			expectedCalls: []string{"first", "last", "cleanup 2", "cleanup 1"},
+		},
+		"GivenFailingStep_ThenRunDeferredSteps": {
+			givenFailingStep: true,
+			expectedCalls:    []string{"first", "cleanup 2", "cleanup 1"},
+			expectedError:    "step 'first' failed: failed",
+		},
+		"GivenFailingStep_WhenDeferredStepFails_ThenAggregateErrors": {
+			givenFailingStep:    true,
+			givenFailingCleanup: true,
+			expectedCalls:       []string{"first", "cleanup 2", "cleanup 1"},
+			expectedError:       "step 'first' failed: failed\nstep 'cleanup 1' failed: failed",
+		},
+		"GivenSuccessfulSteps_WhenDeferredStepFails_ThenReturnDeferredResult": {
+			givenFailingCleanup: true,
+			expectedCalls:       []string{"first", "last", "cleanup 2", "cleanup 1"},
+			expectedError:       "step 'cleanup 1' failed: failed",
+		},
+		"GivenCanceledContext_ThenRunDeferredSteps": {
+			givenCanceled: true,
+			expectedCalls: []string{"cleanup 2", "cleanup 1"},
+			expectedError: "step 'first' failed: context canceled",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			record := func(name string, fail bool) Step[context.Context] {
+				return NewStep(name, func(_ context.Context) error {
+					calls = append(calls, name)
+					if fail {
+						return errors.New("failed")
+					}
+					return nil
+				})
+			}
+			p := NewPipeline[context.Context]().WithSteps(
+				Defer(record("cleanup 1", tc.givenFailingCleanup)),
+				record("first", tc.givenFailingStep),
+				Defer(record("cleanup 2", false)),
+				record("last", false),
+			)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.givenCanceled {
+				cancel()
+			}
+			err := p.RunWithContext(ctx)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
+
+func TestStep_WhenDescribed(t *testing.T) {
+	var reasons []string
+	p := NewPipeline[context.Context]().WithSkippedHooks(func(_ Step[context.Context], reason string) {
+		reasons = append(reasons, reason)
+	})
+	p.WithSteps(
+		p.NewStep("described", failingAction).WhenDescribed(Describe("repository already cloned", Bool[context.Context](false))),
+		p.NewStep("undescribed", failingAction).WhenDescribed(Describe("", Bool[context.Context](false))),
+		p.NewStep("redefined", failingAction).WhenDescribed(Describe("overwritten", Bool[context.Context](true))).When(Bool[context.Context](false)),
+		p.NewStep("run", func(_ context.Context) error { return nil }).WhenDescribed(Describe("not skipped", Bool[context.Context](true))),
+	)
+	err := p.RunWithContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"repository already cloned", "condition evaluated to false", "condition evaluated to false"}, reasons)
+}
+
+func failingAction(_ context.Context) error {
+	return errors.New("should not run")
+}
+
+func succeedingAction(_ context.Context) error {
+	return nil
+}
+
+func TestStep_WithTimeout(t *testing.T) {
+	t.Run("GivenSlowStep_ThenFailWithDeadlineExceeded", func(t *testing.T) {
+		p := NewPipeline[context.Context]()
+		p.WithSteps(
+			p.NewStep("slow", sleepUntilDone(time.Second)).WithTimeout(10*time.Millisecond),
+			p.NewStep("not running", failingAction),
+		)
+		err := p.RunWithContext(context.Background())
+		require.Error(t, err)
+		var result Result
+		require.True(t, errors.As(err, &result))
+		assert.Equal(t, "slow", result.Name())
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("GivenSlowStep_WhenErrorHandled_ThenContinueWithParentContext", func(t *testing.T) {
+		var secondCalled bool
+		p := NewPipeline[context.Context]()
+		p.WithSteps(
+			p.NewStep("slow", sleepUntilDone(time.Second)).WithTimeout(10*time.Millisecond).
+				WithErrorHandler(func(ctx context.Context, err error) error {
+					assert.ErrorIs(t, err, context.DeadlineExceeded)
+					assert.NoError(t, ctx.Err(), "parent context")
+					return nil
+				}),
+			p.NewStep("second", func(ctx context.Context) error {
+				secondCalled = true
+				return ctx.Err()
+			}),
+		)
+		err := p.RunWithContext(context.Background())
+		require.NoError(t, err)
+		assert.True(t, secondCalled)
+	})
+}
+
+func TestStep_WithBeforeHook(t *testing.T) {
+	var calls []string
+	record := func(name string) Listener[context.Context] {
+		return func(step Step[context.Context]) {
+			calls = append(calls, name+": "+step.Name)
+		}
+	}
+	base := NewStep("hooked", func(_ context.Context) error {
+		calls = append(calls, "action")
+		return nil
+	}).WithBeforeHook(record("first"))
+	hooked := base.WithBeforeHook(record("second"))
+
+	p := NewPipeline[context.Context]().WithBeforeHooks(record("global"))
+	p.WithSteps(hooked, NewStep("other", func(_ context.Context) error { return nil }))
+	err := p.RunWithContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"global: hooked", "first: hooked", "second: hooked", "action", "global: other"}, calls)
+	assert.Len(t, base.BeforeHooks, 1, "original step unchanged")
+}
+
+func TestStep_OnSuccess(t *testing.T) {
+	tests := map[string]struct {
+		givenStep     Step[context.Context]
+		givenContext  func() context.Context
+		expectedCalls int
+	}{
+		"GivenSuccessfulStep_ThenCallOnce": {
+			givenStep:     NewStep("success", func(_ context.Context) error { return nil }),
+			expectedCalls: 1,
+		},
+		"GivenFailingStep_ThenDontCall": {
+			givenStep: NewStep("fail", failingAction),
+		},
+		"GivenStepWithRecoveringHandler_ThenCallOnce": {
+			givenStep: NewStep("recovered", failingAction).WithErrorHandler(func(_ context.Context, _ error) error {
+				return nil
+			}),
+			expectedCalls: 1,
+		},
+		"GivenSkippedStep_ThenDontCall": {
+			givenStep: NewStep("skipped", failingAction).When(Bool[context.Context](false)),
+		},
+		"GivenCanceledContext_ThenDontCall": {
+			givenStep: NewStep("canceled", failingAction),
+			givenContext: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			ctx := context.Background()
+			if tc.givenContext != nil {
+				ctx = tc.givenContext()
+			}
+			step := tc.givenStep.OnSuccess(func(_ context.Context) {
+				calls++
+			})
+			_ = NewPipeline[context.Context]().AddStep(step).RunWithContext(ctx)
+			assert.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
+
+func sleepUntilDone(d time.Duration) ActionFunc[context.Context] {
+	return func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d):
+			return nil
+		}
+	}
+}
+
+func ExampleStep_WithTimeout() {
+	p := NewPipeline[context.Context]()
+	p.WithSteps(
+		p.NewStep("long running step", func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second):
+				return nil
+			}
+		}).WithTimeout(10 * time.Millisecond),
+	)
+	err := p.RunWithContext(context.Background())
+	fmt.Println(err)
+	// Output: step 'long running step' failed: context deadline exceeded
+}
+
+func TestStep_WithHeartbeat(t *testing.T) {
+	t.Run("GivenLongRunningAction_ThenInvokeHeartbeats", func(t *testing.T) {
+		defer goleak.VerifyNone(t)
+		var beats int32
+		var lastElapsed atomic.Int64
+		step := NewStep("long", func(_ context.Context) error {
+			time.Sleep(55 * time.Millisecond)
+			return nil
+		}).WithHeartbeat(10*time.Millisecond, func(_ context.Context, elapsed time.Duration) {
+			atomic.AddInt32(&beats, 1)
+			lastElapsed.Store(int64(elapsed))
+		})
+		err := NewPipeline[context.Context]().WithSteps(step).RunWithContext(context.Background())
+		require.NoError(t, err)
+		count := atomic.LoadInt32(&beats)
+		assert.GreaterOrEqual(t, count, int32(3))
+		assert.GreaterOrEqual(t, time.Duration(lastElapsed.Load()), time.Duration(count)*10*time.Millisecond)
+
+		// no more heartbeats after the step completed
+		time.Sleep(30 * time.Millisecond)
+		assert.Equal(t, count, atomic.LoadInt32(&beats))
+	})
+	t.Run("GivenPanickingAction_ThenStopHeartbeat", func(t *testing.T) {
+		defer goleak.VerifyNone(t)
+		step := NewStep("panic", func(_ context.Context) error {
+			time.Sleep(15 * time.Millisecond)
+			panic("boom")
+		}).WithHeartbeat(5*time.Millisecond, func(_ context.Context, _ time.Duration) {})
+		err := NewPipeline[context.Context]().WithOptions(Options{RecoverPanics: true}).WithSteps(step).RunWithContext(context.Background())
+		assert.ErrorIs(t, err, ErrPanic)
+	})
+	t.Run("GivenNonPositiveInterval_ThenPanic", func(t *testing.T) {
+		assert.PanicsWithError(t, `heartbeat interval must be positive for step "step"`, func() {
+			NewStep("step", failingAction).WithHeartbeat(0, func(_ context.Context, _ time.Duration) {})
+		})
+	})
+}
@@ -0,0 +1,27 @@
+package pipeline
+
+import (
+	"time"
+)
+
+// Summary contains statistics of a single pipeline run, see Pipeline.RunWithSummary.
+type Summary struct {
+	// Total is the number of steps in the pipeline.
+	// Steps that aren't reached because the pipeline failed early aren't counted in Ran, Skipped or Failed.
+	Total int
+	// Ran is the number of steps whose action has been invoked.
+	Ran int
+	// Skipped is the number of steps that have been skipped, either due to Step.Condition or Options.CancellationSkipsQuietly.
+	Skipped int
+	// Failed is the number of steps that returned an error, including the step that has been aborted due to a canceled context.
+	Failed int
+	// Duration is the time it took to run the pipeline including its finalizers.
+	Duration time.Duration
+}
+
+// RunWithSummary is similar to RunWithContext, except it additionally returns a Summary of the run, e.g. for reporting.
+func (p *Pipeline[T]) RunWithSummary(ctx T) (Summary, error) {
+	summary := Summary{}
+	err := p.run(ctx, &summary, p.doRun)
+	return summary, err
+}
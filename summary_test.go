@@ -0,0 +1,40 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline_RunWithSummary(t *testing.T) {
+	tests := map[string]struct {
+		givenOptions    Options
+		expectedSummary Summary
+	}{
+		"GivenSkippedAndFailingStep_ThenCountSteps": {
+			expectedSummary: Summary{Total: 4, Ran: 2, Skipped: 1, Failed: 1},
+		},
+		"GivenContinueOnError_ThenCountRemainingSteps": {
+			givenOptions:    Options{ContinueOnError: true},
+			expectedSummary: Summary{Total: 4, Ran: 3, Skipped: 1, Failed: 1},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := NewPipeline[context.Context]().WithOptions(tc.givenOptions)
+			p.WithSteps(
+				p.NewStep("success", func(_ context.Context) error { return nil }),
+				p.When(Bool[context.Context](false), "skipped", func(_ context.Context) error { return nil }),
+				p.NewStep("fail", func(_ context.Context) error { return errors.New("fail") }),
+				p.NewStep("last", func(_ context.Context) error { return nil }),
+			)
+			summary, err := p.RunWithSummary(context.Background())
+			assert.Error(t, err)
+			assert.Positive(t, summary.Duration)
+			summary.Duration = 0
+			assert.Equal(t, tc.expectedSummary, summary)
+		})
+	}
+}
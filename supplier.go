@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"iter"
 )
 
 // Supplier is a function that spawns Pipeline for consumption.
@@ -14,6 +15,10 @@ import (
 // to cancel the supply, otherwise you may leak an orphaned goroutine.
 type Supplier[T context.Context] func(ctx T, pipelinesChan chan *Pipeline[T])
 
+// SupplierE is similar to Supplier, but it can return an error if supplying new pipelines fails, e.g. because a configuration couldn't be loaded.
+// Unlike Supplier, the function must not close the channel, it gets closed once the function has returned.
+type SupplierE[T context.Context] func(ctx T, pipelinesChan chan *Pipeline[T]) error
+
 // SupplierFromSlice returns a Supplier that accepts the given slice of Pipeline and iterates over it to feed the channel.
 //
 // Context cancellation is only effective if the channel is limited in size.
@@ -31,3 +36,20 @@ func SupplierFromSlice[T context.Context](pipelines []*Pipeline[T]) Supplier[T]
 		}
 	}
 }
+
+// SupplierFromSeq returns a Supplier that drains the given iterator to feed the channel.
+// Unlike SupplierFromSlice, the pipelines can be created lazily while they are consumed.
+//
+// The iteration stops if the parent pipeline has been canceled, which is checked before each pipeline is put into the channel.
+func SupplierFromSeq[T context.Context](seq iter.Seq[*Pipeline[T]]) Supplier[T] {
+	return func(ctx T, pipelinesChan chan *Pipeline[T]) {
+		defer close(pipelinesChan)
+		for pipe := range seq {
+			select {
+			case <-ctx.Done():
+				return
+			case pipelinesChan <- pipe:
+			}
+		}
+	}
+}
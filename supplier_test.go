@@ -0,0 +1,79 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestSupplierFromSeq(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	seq := func(yield func(*Pipeline[context.Context]) bool) {
+		for i := 0; i < 3; i++ {
+			p := NewPipeline[context.Context]().AddStepFromFunc(fmt.Sprintf("job %d", i), func(_ context.Context) error {
+				return nil
+			})
+			if !yield(p) {
+				return
+			}
+		}
+	}
+	pipelinesChan := make(chan *Pipeline[context.Context])
+	go SupplierFromSeq[context.Context](seq)(context.Background(), pipelinesChan)
+
+	var names []string
+	for pipe := range pipelinesChan {
+		names = append(names, pipe.StepNames()...)
+	}
+	assert.Equal(t, []string{"job 0", "job 1", "job 2"}, names)
+}
+
+func TestSupplierFromSeq_Cancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	yielded := 0
+	seq := func(yield func(*Pipeline[context.Context]) bool) {
+		for {
+			yielded++
+			if !yield(NewPipeline[context.Context]()) {
+				return
+			}
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	pipelinesChan := make(chan *Pipeline[context.Context])
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		SupplierFromSeq[context.Context](seq)(ctx, pipelinesChan)
+	}()
+
+	<-pipelinesChan
+	cancel()
+	<-done
+	_, open := <-pipelinesChan
+	require.False(t, open, "channel closed")
+	assert.LessOrEqual(t, yielded, 2, "stops the iteration")
+}
+
+func TestSupplierFromSeq_WorkerPool(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	seq := func(yield func(*Pipeline[*testContext]) bool) {
+		for i := 0; i < 3; i++ {
+			p := NewPipeline[*testContext]().AddStepFromFunc("increase", func(ctx *testContext) error {
+				ctx.count++
+				return nil
+			})
+			if !yield(p) {
+				return
+			}
+		}
+	}
+	ctx := &testContext{Context: context.Background()}
+	err := NewWorkerPoolStep[*testContext]("pool", 1, SupplierFromSeq[*testContext](seq), nil).Action(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), ctx.count)
+}
@@ -0,0 +1,33 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+)
+
+// Switch returns a new Step that evaluates the selector and runs the case step whose key matches the result, e.g. to route based on a computed key.
+// If no case matches, the defaultStep runs, or nothing if defaultStep is nil.
+// The Condition and Handler of the chosen step remain effective.
+// An error of the chosen step is wrapped with its name, so that within a pipeline it reads like "step 'switch' failed: step 'case' failed: error".
+func Switch[T context.Context, K comparable](name string, selector func(ctx T) K, cases map[K]Step[T], defaultStep *Step[T]) Step[T] {
+	return NewStep[T](name, func(ctx T) error {
+		chosen, found := cases[selector(ctx)]
+		if !found {
+			if defaultStep == nil {
+				return nil
+			}
+			chosen = *defaultStep
+		}
+		if chosen.Condition != nil && !chosen.Condition(ctx) {
+			return nil
+		}
+		err := chosen.Action(ctx)
+		if chosen.Handler != nil {
+			err = chosen.Handler(ctx, err)
+		}
+		if err != nil {
+			return fmt.Errorf("step '%s' failed: %w", chosen.Name, err)
+		}
+		return nil
+	})
+}
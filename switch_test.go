@@ -0,0 +1,70 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwitch(t *testing.T) {
+	tests := map[string]struct {
+		givenKey      string
+		givenDefault  bool
+		expectedCalls []string
+		expectedError string
+	}{
+		"GivenMatchingKey_ThenRunCase": {
+			givenKey:      "b",
+			givenDefault:  true,
+			expectedCalls: []string{"b"},
+		},
+		"GivenFailingCase_ThenWrapError": {
+			givenKey:      "c",
+			givenDefault:  true,
+			expectedCalls: []string{"c"},
+			expectedError: "step 'switch' failed: step 'c' failed: c failed",
+		},
+		"GivenUnknownKey_WhenDefaultStep_ThenRunDefault": {
+			givenKey:      "unknown",
+			givenDefault:  true,
+			expectedCalls: []string{"default"},
+		},
+		"GivenUnknownKey_WhenNoDefaultStep_ThenDoNothing": {
+			givenKey:      "unknown",
+			expectedCalls: nil,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			record := func(name string, err error) Step[context.Context] {
+				return NewStep(name, func(_ context.Context) error {
+					calls = append(calls, name)
+					return err
+				})
+			}
+			cases := map[string]Step[context.Context]{
+				"a": record("a", nil),
+				"b": record("b", nil),
+				"c": record("c", errors.New("c failed")),
+			}
+			var defaultStep *Step[context.Context]
+			if tc.givenDefault {
+				step := record("default", nil)
+				defaultStep = &step
+			}
+			p := NewPipeline[context.Context]().AddStep(Switch("switch", func(_ context.Context) string {
+				return tc.givenKey
+			}, cases, defaultStep))
+			err := p.RunWithContext(context.Background())
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
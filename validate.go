@@ -0,0 +1,36 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the configuration of the Pipeline without running it, e.g. to fail fast on misconfiguration.
+// It reports steps without Step.Action, steps that depend on unknown steps with Step.DependsOn and Jump steps with an unknown target.
+// Duplicate step names are only reported if Options.RequireUniqueStepNames is enabled.
+// All problems are returned combined with errors.Join, or nil if there are none.
+// The steps of nested pipelines are not validated.
+func (p *Pipeline[T]) Validate() error {
+	var errs []error
+	names := make(map[string]bool, len(p.steps))
+	for i, step := range p.steps {
+		if step.Action == nil {
+			errs = append(errs, fmt.Errorf("step %q at index %d has no action", step.Name, i))
+		}
+		if names[step.Name] && p.options.RequireUniqueStepNames {
+			errs = append(errs, fmt.Errorf("duplicate step name %q", step.Name))
+		}
+		names[step.Name] = true
+	}
+	for _, step := range p.steps {
+		for _, dependency := range step.Dependencies {
+			if !names[dependency] {
+				errs = append(errs, fmt.Errorf("step %q depends on unknown step %q", step.Name, dependency))
+			}
+		}
+		if step.jumpTarget != "" && !names[step.jumpTarget] {
+			errs = append(errs, fmt.Errorf("step %q jumps to unknown step %q", step.Name, step.jumpTarget))
+		}
+	}
+	return errors.Join(errs...)
+}
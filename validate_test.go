@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline_Validate(t *testing.T) {
+	tests := map[string]struct {
+		givenSteps    []Step[context.Context]
+		givenOptions  Options
+		expectedError string
+	}{
+		"GivenValidSteps_ThenReturnNil": {
+			givenSteps: []Step[context.Context]{NewStep("a", failingAction), NewStep("b", failingAction).DependsOn("a")},
+		},
+		"GivenNilAction_ThenReturnError": {
+			givenSteps:    []Step[context.Context]{NewStep("a", failingAction), {Name: "no action"}},
+			expectedError: `step "no action" at index 1 has no action`,
+		},
+		"GivenDuplicateNames_WhenNotRequiredUnique_ThenReturnNil": {
+			givenSteps: []Step[context.Context]{NewStep("a", failingAction), NewStep("a", failingAction)},
+		},
+		"GivenDuplicateNames_WhenRequiredUnique_ThenReturnError": {
+			givenSteps:    []Step[context.Context]{NewStep("a", failingAction), NewStep("a", failingAction)},
+			givenOptions:  Options{RequireUniqueStepNames: true},
+			expectedError: `duplicate step name "a"`,
+		},
+		"GivenUnknownDependency_ThenReturnError": {
+			givenSteps:    []Step[context.Context]{NewStep("a", failingAction).DependsOn("unknown")},
+			expectedError: `step "a" depends on unknown step "unknown"`,
+		},
+		"GivenUnknownJumpTarget_ThenReturnError": {
+			givenSteps:    []Step[context.Context]{NewStep("a", failingAction), Jump[context.Context]("unknown")},
+			expectedError: `step "jump to unknown" jumps to unknown step "unknown"`,
+		},
+		"GivenMultipleProblems_ThenReturnAllErrors": {
+			givenSteps:    []Step[context.Context]{{Name: "a"}, NewStep("a", failingAction).DependsOn("b")},
+			givenOptions:  Options{RequireUniqueStepNames: true},
+			expectedError: "step \"a\" at index 0 has no action\nduplicate step name \"a\"\nstep \"a\" depends on unknown step \"b\"",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := NewPipeline[context.Context]().WithOptions(tc.givenOptions).WithSteps(tc.givenSteps...)
+			err := p.Validate()
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}